@@ -1,8 +1,6 @@
 module github.com/ahawker/stdlibx-go
 
-go 1.22.0
-
-toolchain go1.22.1
+go 1.23.0
 
 require (
 	golang.org/x/exp v0.0.0-20240909161429-701f63a606c0
@@ -0,0 +1,374 @@
+package stdlib
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	_ Clock = RealClock{}
+	_ Clock = (*FakeClock)(nil)
+)
+
+// DefaultClock is the clock used when one is not explicitly configured.
+var DefaultClock Clock = RealClock{}
+
+// Clock describes a source of time that can be swapped out, e.g. to control
+// the passage of time in tests.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// After waits for the duration to elapse and then sends the current time on the returned channel.
+	After(d time.Duration) <-chan time.Time
+	// NewTimer creates a new Timer that fires after the given duration.
+	NewTimer(d time.Duration) Timer
+	// NewTicker creates a new Ticker that fires every period.
+	NewTicker(d time.Duration) Ticker
+	// Sleep pauses the current goroutine for at least the duration.
+	Sleep(d time.Duration)
+	// WithTimeout returns a copy of parent that is cancelled after the duration.
+	//
+	// It is equivalent to [context.WithTimeout] for this clock.
+	WithTimeout(parent context.Context, d time.Duration) (context.Context, context.CancelFunc)
+	// WithTimeoutCause returns a copy of parent that is cancelled with the cause after the duration.
+	//
+	// It is equivalent to [context.WithTimeoutCause] for this clock.
+	WithTimeoutCause(parent context.Context, d time.Duration, cause error) (context.Context, context.CancelFunc)
+}
+
+// Timer describes a single event timer created by a Clock.
+type Timer interface {
+	// C returns the channel on which the time is delivered when the timer fires.
+	C() <-chan time.Time
+	// Stop prevents the timer from firing.
+	Stop() bool
+	// Reset changes the timer to fire after the given duration.
+	Reset(d time.Duration) bool
+}
+
+// Ticker describes a periodic timer created by a Clock.
+type Ticker interface {
+	// C returns the channel on which the ticks are delivered.
+	C() <-chan time.Time
+	// Stop turns off the ticker.
+	Stop()
+	// Reset stops the ticker and resets its period to the given duration.
+	Reset(d time.Duration)
+}
+
+// RealClock is a Clock backed by the stdlib 'time' package.
+type RealClock struct{}
+
+// Now returns the current time.
+func (RealClock) Now() time.Time { return time.Now() }
+
+// After waits for the duration to elapse and then sends the current time on the returned channel.
+func (RealClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// NewTimer creates a new Timer that fires after the given duration.
+func (RealClock) NewTimer(d time.Duration) Timer { return &realTimer{time.NewTimer(d)} }
+
+// NewTicker creates a new Ticker that fires every period.
+func (RealClock) NewTicker(d time.Duration) Ticker { return &realTicker{time.NewTicker(d)} }
+
+// Sleep pauses the current goroutine for at least the duration.
+func (RealClock) Sleep(d time.Duration) { time.Sleep(d) }
+
+// WithTimeout returns a copy of parent that is cancelled after the duration.
+func (RealClock) WithTimeout(parent context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(parent, d)
+}
+
+// WithTimeoutCause returns a copy of parent that is cancelled with the cause after the duration.
+func (RealClock) WithTimeoutCause(
+	parent context.Context,
+	d time.Duration,
+	cause error,
+) (context.Context, context.CancelFunc) {
+	return context.WithTimeoutCause(parent, d, cause)
+}
+
+// realTimer adapts a *time.Timer to the Timer interface.
+type realTimer struct{ t *time.Timer }
+
+// C returns the channel on which the time is delivered.
+func (t *realTimer) C() <-chan time.Time { return t.t.C }
+
+// Stop prevents the timer from firing.
+func (t *realTimer) Stop() bool { return t.t.Stop() }
+
+// Reset changes the timer to fire after the given duration.
+func (t *realTimer) Reset(d time.Duration) bool { return t.t.Reset(d) }
+
+// realTicker adapts a *time.Ticker to the Ticker interface.
+type realTicker struct{ t *time.Ticker }
+
+// C returns the channel on which the ticks are delivered.
+func (t *realTicker) C() <-chan time.Time { return t.t.C }
+
+// Stop turns off the ticker.
+func (t *realTicker) Stop() { t.t.Stop() }
+
+// Reset stops the ticker and resets its period to the given duration.
+func (t *realTicker) Reset(d time.Duration) { t.t.Reset(d) }
+
+// NewFakeClock creates a new *FakeClock set to the given time.
+func NewFakeClock(now time.Time) *FakeClock {
+	c := &FakeClock{now: now}
+	c.cond = sync.NewCond(&c.mu)
+	return c
+}
+
+// FakeClock is a Clock whose time only moves when 'Advance' is called.
+//
+// Timers, tickers, sleeps and timeouts registered with the clock fire
+// synchronously during 'Advance' in deadline order, with ties broken by
+// registration order, which makes time dependent tests deterministic.
+type FakeClock struct {
+	// mu guards all fields.
+	mu sync.Mutex
+	// cond is signalled when the set of waiters changes.
+	cond *sync.Cond
+	// now is the current fake time.
+	now time.Time
+	// seq is the registration counter used to order waiters with equal deadlines.
+	seq uint64
+	// waiters are the active timers, tickers, sleeps and timeouts.
+	waiters []*fakeWaiter
+}
+
+// Now returns the current fake time.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// After waits for the duration to elapse and then sends the current time on the returned channel.
+func (c *FakeClock) After(d time.Duration) <-chan time.Time {
+	return c.NewTimer(d).C()
+}
+
+// NewTimer creates a new Timer that fires after the given duration.
+func (c *FakeClock) NewTimer(d time.Duration) Timer {
+	w := &fakeWaiter{clock: c, ch: make(chan time.Time, 1)}
+	c.register(w, d)
+	return w
+}
+
+// NewTicker creates a new Ticker that fires every period.
+func (c *FakeClock) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("non-positive interval for FakeClock.NewTicker")
+	}
+	w := &fakeWaiter{clock: c, ch: make(chan time.Time, 1), period: d}
+	c.register(w, d)
+	return &fakeTicker{w}
+}
+
+// Sleep blocks until the clock has been advanced by at least the duration.
+func (c *FakeClock) Sleep(d time.Duration) {
+	<-c.After(d)
+}
+
+// WithTimeout returns a copy of parent that is cancelled once the clock
+// has been advanced by the duration.
+func (c *FakeClock) WithTimeout(parent context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	return c.WithTimeoutCause(parent, d, context.DeadlineExceeded)
+}
+
+// WithTimeoutCause returns a copy of parent that is cancelled with the cause once the
+// clock has been advanced by the duration.
+func (c *FakeClock) WithTimeoutCause(
+	parent context.Context,
+	d time.Duration,
+	cause error,
+) (context.Context, context.CancelFunc) {
+	inner, cancel := context.WithCancelCause(parent)
+	ctx := &fakeTimeoutContext{Context: inner, deadline: c.Now().Add(d)}
+
+	w := &fakeWaiter{clock: c, fn: func() {
+		if inner.Err() == nil {
+			ctx.expired.Store(true)
+			cancel(cause)
+		}
+	}}
+	c.register(w, d)
+
+	return ctx, func() {
+		w.Stop()
+		cancel(context.Canceled)
+	}
+}
+
+// Advance moves the clock forward by the duration, firing all waiters whose
+// deadline is reached along the way.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	target := c.now.Add(d)
+	for {
+		w := c.next(target)
+		if w == nil {
+			break
+		}
+		c.now = w.deadline
+		if w.period > 0 {
+			w.deadline = w.deadline.Add(w.period)
+		} else {
+			c.remove(w)
+		}
+
+		// Fire outside the lock so callbacks may use the clock.
+		now := c.now
+		c.mu.Unlock()
+		w.fire(now)
+		c.mu.Lock()
+	}
+	c.now = target
+	c.mu.Unlock()
+}
+
+// BlockUntil blocks until at least n timers, tickers, sleeps or timeouts
+// are registered with the clock.
+func (c *FakeClock) BlockUntil(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for len(c.waiters) < n {
+		c.cond.Wait()
+	}
+}
+
+// Waiters returns the number of timers, tickers, sleeps and timeouts registered with the clock.
+func (c *FakeClock) Waiters() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.waiters)
+}
+
+// register adds the waiter to fire after the duration.
+func (c *FakeClock) register(w *fakeWaiter, d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.seq++
+	w.seq = c.seq
+	w.deadline = c.now.Add(d)
+	c.waiters = append(c.waiters, w)
+	c.cond.Broadcast()
+}
+
+// remove deletes the waiter and reports whether it was registered.
+//
+// Callers must hold the lock.
+func (c *FakeClock) remove(w *fakeWaiter) bool {
+	for i, other := range c.waiters {
+		if other == w {
+			c.waiters = append(c.waiters[:i], c.waiters[i+1:]...)
+			c.cond.Broadcast()
+			return true
+		}
+	}
+	return false
+}
+
+// next returns the waiter with the earliest deadline not after target.
+//
+// Callers must hold the lock.
+func (c *FakeClock) next(target time.Time) *fakeWaiter {
+	sort.SliceStable(c.waiters, func(i, j int) bool {
+		a, b := c.waiters[i], c.waiters[j]
+		if a.deadline.Equal(b.deadline) {
+			return a.seq < b.seq
+		}
+		return a.deadline.Before(b.deadline)
+	})
+	if len(c.waiters) == 0 || c.waiters[0].deadline.After(target) {
+		return nil
+	}
+	return c.waiters[0]
+}
+
+// fakeWaiter is a timer, ticker, sleep or timeout registered with a FakeClock.
+type fakeWaiter struct {
+	clock    *FakeClock
+	seq      uint64
+	deadline time.Time
+	period   time.Duration
+	ch       chan time.Time
+	fn       func()
+}
+
+// C returns the channel on which the time is delivered.
+func (w *fakeWaiter) C() <-chan time.Time { return w.ch }
+
+// Stop prevents the waiter from firing.
+func (w *fakeWaiter) Stop() bool {
+	w.clock.mu.Lock()
+	defer w.clock.mu.Unlock()
+	return w.clock.remove(w)
+}
+
+// Reset changes the waiter to fire after the given duration.
+func (w *fakeWaiter) Reset(d time.Duration) bool {
+	w.clock.mu.Lock()
+	active := w.clock.remove(w)
+	if w.period > 0 {
+		w.period = d
+	}
+	w.clock.mu.Unlock()
+
+	w.clock.register(w, d)
+	return active
+}
+
+// fire delivers the time to the channel or calls the callback.
+func (w *fakeWaiter) fire(now time.Time) {
+	if w.fn != nil {
+		w.fn()
+		return
+	}
+	// Drop the tick if the receiver is behind, matching stdlib timer semantics.
+	select {
+	case w.ch <- now:
+	default:
+	}
+}
+
+// fakeTicker adapts a periodic fakeWaiter to the Ticker interface.
+type fakeTicker struct{ w *fakeWaiter }
+
+// C returns the channel on which the ticks are delivered.
+func (t *fakeTicker) C() <-chan time.Time { return t.w.ch }
+
+// Stop turns off the ticker.
+func (t *fakeTicker) Stop() { t.w.Stop() }
+
+// Reset stops the ticker and resets its period to the given duration.
+func (t *fakeTicker) Reset(d time.Duration) { t.w.Reset(d) }
+
+// fakeTimeoutContext is a context cancelled by a FakeClock deadline.
+type fakeTimeoutContext struct {
+	context.Context
+	deadline time.Time
+	expired  atomic.Bool
+}
+
+// Deadline returns the earlier of the fake deadline and the parent deadline.
+func (c *fakeTimeoutContext) Deadline() (time.Time, bool) {
+	if parent, ok := c.Context.Deadline(); ok && parent.Before(c.deadline) {
+		return parent, true
+	}
+	return c.deadline, true
+}
+
+// Err returns context.DeadlineExceeded if the fake deadline was reached.
+func (c *fakeTimeoutContext) Err() error {
+	err := c.Context.Err()
+	if err != nil && c.expired.Load() {
+		return context.DeadlineExceeded
+	}
+	return err
+}
@@ -0,0 +1,98 @@
+package stdlib_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ahawker/stdlibx-go/stdlib"
+	"github.com/ahawker/stdlibx-go/stdtest"
+)
+
+var epoch = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+func TestFakeClockAdvance(t *testing.T) {
+	clock := stdlib.NewFakeClock(epoch)
+	test := stdtest.NewTest(t, stdtest.WithTestClock(clock))
+
+	timer := clock.NewTimer(2 * time.Second)
+	ticker := clock.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	clock.Advance(time.Second)
+	test.Equal(<-ticker.C(), epoch.Add(time.Second))
+	select {
+	case <-timer.C():
+		test.Fatal("timer fired early")
+	default:
+	}
+
+	clock.Advance(time.Second)
+	test.Equal(<-timer.C(), epoch.Add(2*time.Second))
+	test.Equal(<-ticker.C(), epoch.Add(2*time.Second))
+	test.Equal(clock.Now(), epoch.Add(2*time.Second))
+	test.Equal(clock.Waiters(), 1)
+}
+
+func TestFakeClockWithTimeout(t *testing.T) {
+	clock := stdlib.NewFakeClock(epoch)
+	test := stdtest.NewTest(t, stdtest.WithTestClock(clock))
+
+	ctx, cancel := clock.WithTimeout(test.Config.Context, time.Minute)
+	defer cancel()
+
+	deadline, ok := ctx.Deadline()
+	test.True(ok, "want deadline")
+	test.Equal(deadline, epoch.Add(time.Minute))
+
+	clock.Advance(59 * time.Second)
+	test.OK(ctx.Err())
+
+	clock.Advance(time.Second)
+	<-ctx.Done()
+	test.EqualError(ctx.Err(), context.DeadlineExceeded)
+}
+
+func TestTaskRunFakeClock(t *testing.T) {
+	clock := stdlib.NewFakeClock(epoch)
+	test := stdtest.NewTest(t, stdtest.WithTestClock(clock))
+
+	result := make(chan error, 1)
+	go func() {
+		result <- stdlib.Task(test.Config.Context, func(ctx context.Context) error {
+			test.Config.Clock.Sleep(time.Hour)
+			return nil
+		}, stdlib.WithTaskTimeout(time.Minute), stdlib.WithTaskClock(test.Config.Clock))
+	}()
+
+	// Wait for the task timeout and the sleep to register before advancing.
+	clock.BlockUntil(2)
+	clock.Advance(time.Hour)
+	test.EqualError(<-result, stdlib.ErrTaskTimeout)
+}
+
+func TestSchedulerFakeClock(t *testing.T) {
+	clock := stdlib.NewFakeClock(epoch)
+	test := stdtest.NewTest(t, stdtest.WithTestClock(clock))
+
+	s, err := stdlib.NewScheduler(test.Config.Context, stdlib.WithSchedulerClock(clock))
+	test.OK(err)
+	test.Closeup(s)
+
+	var runs atomic.Int32
+	ran := make(chan struct{})
+	err = s.Add("every-second", stdlib.ScheduleEvery(time.Second, 0), func(ctx context.Context) error {
+		runs.Add(1)
+		ran <- struct{}{}
+		return nil
+	})
+	test.OK(err)
+
+	for i := 0; i < 3; i++ {
+		clock.BlockUntil(1)
+		clock.Advance(time.Second)
+		<-ran
+	}
+	test.Equal(runs.Load(), int32(3))
+}
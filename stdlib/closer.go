@@ -1,10 +1,27 @@
 package stdlib
 
 import (
+	"context"
+	"fmt"
 	"io"
+	"sync"
 )
 
-var _ io.Closer = CloserFn(nil)
+var (
+	_ io.Closer     = CloserFn(nil)
+	_ io.Closer     = ContextCloserFn(nil)
+	_ ContextCloser = ContextCloserFn(nil)
+	_ io.Closer     = NamedCloser{}
+	_ ContextCloser = NamedCloser{}
+	_ io.Closer     = (*CloserGroup)(nil)
+	_ ContextCloser = (*CloserGroup)(nil)
+)
+
+// ContextCloser describes types that close resources and respect context cancellation.
+type ContextCloser interface {
+	// CloseContext closes the resource, giving up when the context is done.
+	CloseContext(ctx context.Context) error
+}
 
 // CloserFn is a function that can be used to close resources.
 type CloserFn func() error
@@ -13,6 +30,48 @@ func (fn CloserFn) Close() error {
 	return fn()
 }
 
+// ContextCloserFn is a function that can be used to close resources with a context.
+type ContextCloserFn func(ctx context.Context) error
+
+// Close closes the resource with a background context.
+//
+// Interface: io.Closer.
+func (fn ContextCloserFn) Close() error {
+	return fn(context.Background())
+}
+
+// CloseContext closes the resource with the given context.
+//
+// Interface: ContextCloser.
+func (fn ContextCloserFn) CloseContext(ctx context.Context) error {
+	return fn(ctx)
+}
+
+// NamedCloser pairs a closer with a name used to identify the errors it returns.
+type NamedCloser struct {
+	// Name of the closer.
+	Name string
+	// Closer to close. If it also implements ContextCloser, that is preferred.
+	Closer io.Closer
+}
+
+// Close closes the resource.
+//
+// Interface: io.Closer.
+func (c NamedCloser) Close() error {
+	return c.CloseContext(context.Background())
+}
+
+// CloseContext closes the resource, passing the context when the closer supports it.
+//
+// Interface: ContextCloser.
+func (c NamedCloser) CloseContext(ctx context.Context) error {
+	if cc, ok := c.Closer.(ContextCloser); ok {
+		return cc.CloseContext(ctx)
+	}
+	return c.Closer.Close()
+}
+
 // NewCloserGroup creates a new *CloserGroup with sane defaults.
 func NewCloserGroup(closers ...io.Closer) *CloserGroup {
 	cg := &CloserGroup{
@@ -23,45 +82,156 @@ func NewCloserGroup(closers ...io.Closer) *CloserGroup {
 }
 
 // CloserGroup is a collection of io.Closer instances that can be closed together.
+//
+// Closers are closed in the order they were added. Errors are tagged with the
+// name of the closer that returned them. Closing is idempotent and safe for
+// concurrent use. Closers appended once the group is closing are closed
+// immediately and their errors added to the result of the group.
 type CloserGroup struct {
+	// Closers in the group, as NamedCloser instances when added with the
+	// Append methods. Use them instead of modifying it directly, since it is
+	// guarded by the group for concurrent use.
 	Closers []io.Closer
+	// mu guards all fields.
+	mu sync.Mutex
+	// done is created when the group starts closing and closed once it has.
+	done chan struct{}
+	// err is the result of closing the group.
+	err error
 }
 
 // Append adds the given closers to the group.
+//
+// NamedCloser instances keep their name while others are named by their type.
 func (g *CloserGroup) Append(closers ...io.Closer) {
 	for _, closer := range closers {
 		if closer == nil {
 			continue
 		}
 
-		// When given a closer that's a group, we want to flatten & merge
-		// the items.
-		if cg, ok := closer.(*CloserGroup); ok {
-			cg.Append(cg.Closers...)
-			continue
+		switch c := closer.(type) {
+		case *CloserGroup:
+			// When given a closer that's a group, we want to flatten & merge
+			// the items.
+			if c != g {
+				for _, closer := range c.snapshot() {
+					g.append(closerNamed(closer))
+				}
+			}
+		default:
+			g.append(closerNamed(closer))
 		}
+	}
+}
+
+// AppendNamed adds the named closer to the group.
+func (g *CloserGroup) AppendNamed(name string, closer io.Closer) {
+	if closer == nil {
+		return
+	}
+	g.append(NamedCloser{Name: name, Closer: closer})
+}
 
-		g.Closers = append(g.Closers, closer)
+// AppendContext adds the named context-aware closer to the group.
+func (g *CloserGroup) AppendContext(name string, closer ContextCloser) {
+	if closer == nil {
+		return
 	}
+	g.append(NamedCloser{Name: name, Closer: ContextCloserFn(closer.CloseContext)})
 }
 
 // Close closes all closers in the group.
+//
+// Interface: io.Closer.
 func (g *CloserGroup) Close() error {
+	return g.CloseContext(context.Background())
+}
+
+// CloseContext closes all closers in the group with the given context.
+//
+// The group is not locked while closing, so closers may append to it. Subsequent
+// calls wait for the first to complete and return its result.
+//
+// Interface: ContextCloser.
+func (g *CloserGroup) CloseContext(ctx context.Context) error {
+	g.mu.Lock()
+	if done := g.done; done != nil {
+		g.mu.Unlock()
+		<-done
+
+		g.mu.Lock()
+		defer g.mu.Unlock()
+		return g.err
+	}
+	g.done = make(chan struct{})
+	closers := make([]io.Closer, len(g.Closers))
+	copy(closers, g.Closers)
+	g.mu.Unlock()
+
 	eg := NewErrorGroup()
-	for _, closer := range g.Closers {
-		eg.Append(closer.Close())
+	for _, closer := range closers {
+		eg.Append(closerClose(ctx, closerNamed(closer)))
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	// Errors of closers appended while closing are already recorded.
+	g.err = NewErrorGroup(eg, g.err).ErrorOrNil()
+	close(g.done)
+	return g.err
+}
+
+// append adds the closer to the group, closing it immediately if the group is closing.
+func (g *CloserGroup) append(closer NamedCloser) {
+	g.mu.Lock()
+	g.Closers = append(g.Closers, closer)
+	closing := g.done != nil
+	g.mu.Unlock()
+
+	if !closing {
+		return
+	}
+	if err := closerClose(context.Background(), closer); err != nil {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+		g.err = NewErrorGroup(g.err, err).ErrorOrNil()
+	}
+}
+
+// snapshot returns a copy of the closers in the group.
+func (g *CloserGroup) snapshot() []io.Closer {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	closers := make([]io.Closer, len(g.Closers))
+	copy(closers, g.Closers)
+	return closers
+}
+
+// closerNamed returns the closer as a NamedCloser, named by its type if it isn't one.
+func closerNamed(closer io.Closer) NamedCloser {
+	if c, ok := closer.(NamedCloser); ok {
+		return c
+	}
+	return NamedCloser{Name: fmt.Sprintf("%T", closer), Closer: closer}
+}
+
+// closerClose closes the named closer and tags its error with the name.
+func closerClose(ctx context.Context, closer NamedCloser) error {
+	if err := closer.CloseContext(ctx); err != nil {
+		return errorWithTag(err, closer.Name)
 	}
-	return eg.ErrorOrNil()
+	return nil
 }
 
 // CloserJoin is a helper function that will append more closers
 // onto an CloserGroup.
 //
-// If err is not already an ErrorGroup, then it will be turned into
-// one. If any of the errs are ErrorGroup, they will be flattened
-// one level into err.
-// Any nil errors within errs will be ignored. If err is nil, a new
-// *ErrorGroup will be returned containing the given errs.
+// If closer is not already a CloserGroup, then it will be turned into
+// one. If any of the closers are CloserGroup, they will be flattened
+// one level into closer.
+// Any nil closers will be ignored.
 func CloserJoin(closer io.Closer, closers ...io.Closer) *CloserGroup {
 	if cg, ok := closer.(*CloserGroup); ok {
 		cg.Append(closers...)
@@ -0,0 +1,95 @@
+package stdlib_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/ahawker/stdlibx-go/stdlib"
+	"github.com/ahawker/stdlibx-go/stdtest"
+)
+
+func TestCloserGroup(t *testing.T) {
+	test := stdtest.NewTest(t)
+
+	var closed []string
+	closer := func(name string, err error) stdlib.CloserFn {
+		return func() error {
+			closed = append(closed, name)
+			return err
+		}
+	}
+
+	nested := stdlib.NewCloserGroup(closer("nested", fmt.Errorf("nested failed")))
+	g := stdlib.NewCloserGroup(closer("first", nil), nested)
+	g.AppendNamed("named", closer("named", fmt.Errorf("named failed")))
+	g.AppendContext("context", stdlib.ContextCloserFn(func(ctx context.Context) error {
+		closed = append(closed, "context")
+		return ctx.Err()
+	}))
+	test.Equal(len(g.Closers), 4)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := g.CloseContext(ctx)
+	test.NotOK(err)
+	test.Equal(closed, []string{"first", "nested", "named", "context"})
+
+	eg, ok := err.(*stdlib.ErrorGroup)
+	test.True(ok, "want *stdlib.ErrorGroup got %T", err)
+	var tags []string
+	for _, e := range eg.Errors {
+		tags = append(tags, e.Extras.Tags...)
+	}
+	test.Equal(tags, []string{"stdlib.CloserFn", "named", "context"})
+
+	// Close is idempotent and safe for concurrent use.
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			test.Equal(g.Close(), err)
+		}()
+	}
+	wg.Wait()
+	test.Equal(len(closed), 4)
+}
+
+func TestCloserGroupAppendWhileClosing(t *testing.T) {
+	test := stdtest.NewTest(t)
+
+	var closed []string
+	closer := func(name string, err error) stdlib.CloserFn {
+		return func() error {
+			closed = append(closed, name)
+			return err
+		}
+	}
+
+	// Closers may append to the group they are closed by.
+	g := stdlib.NewCloserGroup()
+	g.AppendNamed("first", stdlib.CloserFn(func() error {
+		closed = append(closed, "first")
+		g.AppendNamed("during", closer("during", fmt.Errorf("during failed")))
+		return nil
+	}))
+	err := g.Close()
+	test.NotOK(err)
+	test.Equal(closed, []string{"first", "during"})
+
+	// Closers appended after the group is closed are closed immediately.
+	g.AppendNamed("after", closer("after", fmt.Errorf("after failed")))
+	test.Equal(closed, []string{"first", "during", "after"})
+	test.Equal(len(g.Closers), 3)
+
+	eg, ok := g.Close().(*stdlib.ErrorGroup)
+	test.True(ok, "want *stdlib.ErrorGroup")
+	var tags []string
+	for _, e := range eg.Errors {
+		tags = append(tags, e.Extras.Tags...)
+	}
+	test.Equal(tags, []string{"during", "after"})
+}
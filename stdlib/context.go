@@ -4,19 +4,83 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"sync"
 )
 
 // Based on https://github.com/tailscale/tailscale/blob/main/util/ctxkey/key.go
 
+// DefaultContextKeyRegistry is the registry used by 'ContextValues', 'ContextSnapshot'
+// and log handlers by default. Keys are only added to it with 'WithContextKeyRegistered'.
+var DefaultContextKeyRegistry = NewContextKeyRegistry()
+
+// ContextKeyConfig is the metadata of a context key.
+type ContextKeyConfig struct {
+	// Propagated keys are captured by context snapshots.
+	Propagated bool
+	// Logged keys are emitted on log records.
+	Logged bool
+	// Redacted keys have their value hidden when logged.
+	Redacted bool
+	// Registry the key is added to. Nil, the default, skips registration.
+	Registry *ContextKeyRegistry
+}
+
+// WithContextKeyPropagated marks the key to be captured by context snapshots.
+func WithContextKeyPropagated() Option[*ContextKeyConfig] {
+	return func(o *ContextKeyConfig) error {
+		o.Propagated = true
+		return nil
+	}
+}
+
+// WithContextKeyLogged marks the key to be emitted on log records.
+func WithContextKeyLogged() Option[*ContextKeyConfig] {
+	return func(o *ContextKeyConfig) error {
+		o.Logged = true
+		return nil
+	}
+}
+
+// WithContextKeyRedacted marks the key to have its value hidden when logged.
+func WithContextKeyRedacted() Option[*ContextKeyConfig] {
+	return func(o *ContextKeyConfig) error {
+		o.Redacted = true
+		return nil
+	}
+}
+
+// WithContextKeyRegistered adds the key to the DefaultContextKeyRegistry.
+func WithContextKeyRegistered() Option[*ContextKeyConfig] {
+	return WithContextKeyRegistry(DefaultContextKeyRegistry)
+}
+
+// WithContextKeyRegistry sets the registry the key is added to.
+func WithContextKeyRegistry(registry *ContextKeyRegistry) Option[*ContextKeyConfig] {
+	return func(o *ContextKeyConfig) error {
+		o.Registry = registry
+		return nil
+	}
+}
+
 // NewContextKey creates a new context key for a generic type.
-func NewContextKey[T any](name string, def T) ContextKey[T] {
+//
+// The key is only added to a registry when one is given, e.g. with
+// 'WithContextKeyRegistered'. It panics if an option returns an error.
+func NewContextKey[T any](name string, def T, options ...Option[*ContextKeyConfig]) ContextKey[T] {
 	if name == "" {
 		name = reflect.TypeFor[T]().String()
 	}
-	key := ContextKey[T]{name: &stringer[string]{name}}
+	cfg, err := OptionApply(&ContextKeyConfig{}, options...)
+	if err != nil {
+		panic(fmt.Sprintf("NewContextKey[%s] received invalid option: %v", name, err))
+	}
+	key := ContextKey[T]{name: &stringer[string]{name}, config: cfg}
 	if dv := reflect.ValueOf(def); dv.IsValid() && !dv.IsZero() {
 		key.def = &def
 	}
+	if cfg.Registry != nil {
+		cfg.Registry.register(key.name, *cfg)
+	}
 	return key
 }
 
@@ -24,8 +88,9 @@ func NewContextKey[T any](name string, def T) ContextKey[T] {
 // should be used with non-exported Go types to avoid potential key collisions
 // within the context object.
 type ContextKey[T any] struct {
-	name *stringer[string]
-	def  *T
+	name   *stringer[string]
+	def    *T
+	config *ContextKeyConfig
 }
 
 // WithValue returns a copy of parent in which the value associated with key is value.
@@ -58,6 +123,142 @@ func (k ContextKey[T]) String() string {
 	return k.name.String()
 }
 
+// Config returns the metadata of the key.
+func (k ContextKey[T]) Config() ContextKeyConfig {
+	if k.config == nil {
+		return ContextKeyConfig{}
+	}
+	return *k.config
+}
+
+// NewContextKeyRegistry creates a new, empty *ContextKeyRegistry.
+func NewContextKeyRegistry() *ContextKeyRegistry {
+	return &ContextKeyRegistry{}
+}
+
+// ContextKeyRegistry tracks context keys so the values of a context can be
+// listed and copied without knowing their types.
+type ContextKeyRegistry struct {
+	// mu guards keys.
+	mu sync.RWMutex
+	// keys in registration order.
+	keys []contextKeyEntry
+}
+
+// Names returns the names of the registered keys in registration order.
+func (r *ContextKeyRegistry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, len(r.keys))
+	for i, key := range r.keys {
+		names[i] = key.name.String()
+	}
+	return names
+}
+
+// Values returns the values of all registered keys present in the context.
+func (r *ContextKeyRegistry) Values(ctx context.Context) []ContextValue {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	values := make([]ContextValue, 0, len(r.keys))
+	for _, key := range r.keys {
+		if v, ok := ctx.Value(key.name).(contextValue); ok {
+			values = append(values, ContextValue{Name: key.name.String(), Value: v.value(), Config: key.config})
+		}
+	}
+	return values
+}
+
+// Snapshot captures the values of all propagated keys present in the context.
+func (r *ContextKeyRegistry) Snapshot(ctx context.Context) ContextValueSnapshot {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var snap ContextValueSnapshot
+	for _, key := range r.keys {
+		if !key.config.Propagated {
+			continue
+		}
+		if v := ctx.Value(key.name); v != nil {
+			snap.values = append(snap.values, contextSnapshotValue{key: key.name, value: v})
+		}
+	}
+	return snap
+}
+
+// register adds the key to the registry.
+func (r *ContextKeyRegistry) register(name *stringer[string], config ContextKeyConfig) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.keys = append(r.keys, contextKeyEntry{name: name, config: config})
+}
+
+// ContextValue is the value of a registered key found in a context.
+type ContextValue struct {
+	// Name of the key.
+	Name string
+	// Value associated with the key.
+	Value any
+	// Config is the metadata of the key.
+	Config ContextKeyConfig
+}
+
+// ContextValues returns the values of all keys in the DefaultContextKeyRegistry
+// present in the context.
+func ContextValues(ctx context.Context) []ContextValue {
+	return DefaultContextKeyRegistry.Values(ctx)
+}
+
+// ContextSnapshot captures the values of all propagated keys in the
+// DefaultContextKeyRegistry present in the context.
+func ContextSnapshot(ctx context.Context) ContextValueSnapshot {
+	return DefaultContextKeyRegistry.Snapshot(ctx)
+}
+
+// ContextRestore returns a copy of ctx with the snapshot values applied, e.g. on
+// a 'context.Background()' for detached work.
+func ContextRestore(ctx context.Context, snap ContextValueSnapshot) context.Context {
+	return snap.Restore(ctx)
+}
+
+// ContextValueSnapshot is a set of context values captured from a context.
+type ContextValueSnapshot struct {
+	values []contextSnapshotValue
+}
+
+// Len returns the number of captured values.
+func (s ContextValueSnapshot) Len() int {
+	return len(s.values)
+}
+
+// Restore returns a copy of ctx with the snapshot values applied.
+func (s ContextValueSnapshot) Restore(ctx context.Context) context.Context {
+	for _, v := range s.values {
+		ctx = context.WithValue(ctx, v.key, v.value)
+	}
+	return ctx
+}
+
+// contextKeyEntry is a key tracked by a registry.
+type contextKeyEntry struct {
+	name   *stringer[string]
+	config ContextKeyConfig
+}
+
+// contextSnapshotValue is a value captured by a snapshot.
+type contextSnapshotValue struct {
+	key   *stringer[string]
+	value any
+}
+
+// contextValue is implemented by values stored in a context to
+// access them without knowing their type.
+type contextValue interface {
+	value() any
+}
+
 // stringer supports the 'fmt.Stringer' interface for arbitrary generic types.
 type stringer[T any] struct{ t T }
 
@@ -65,3 +266,8 @@ type stringer[T any] struct{ t T }
 func (g stringer[T]) String() string {
 	return fmt.Sprint(g.t)
 }
+
+// value returns the wrapped value.
+func (g stringer[T]) value() any {
+	return g.t
+}
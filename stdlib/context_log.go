@@ -0,0 +1,101 @@
+package stdlib
+
+import (
+	"context"
+	"log/slog"
+)
+
+var _ slog.Handler = (*ContextLogHandler)(nil)
+
+// ContextLogRedacted is the value logged in place of redacted context values.
+const ContextLogRedacted = "[REDACTED]"
+
+// ContextLogHandlerConfig for a context log handler.
+type ContextLogHandlerConfig struct {
+	// Registry of keys to emit.
+	Registry *ContextKeyRegistry
+	// Group is the name of the group values are emitted under. Empty emits them
+	// as top level attributes.
+	Group string
+}
+
+// WithContextLogHandlerRegistry sets the registry of keys to emit.
+func WithContextLogHandlerRegistry(registry *ContextKeyRegistry) Option[*ContextLogHandlerConfig] {
+	return func(o *ContextLogHandlerConfig) error {
+		o.Registry = registry
+		return nil
+	}
+}
+
+// WithContextLogHandlerGroup sets the name of the group values are emitted under.
+func WithContextLogHandlerGroup(group string) Option[*ContextLogHandlerConfig] {
+	return func(o *ContextLogHandlerConfig) error {
+		o.Group = group
+		return nil
+	}
+}
+
+// NewContextLogHandler creates a new *ContextLogHandler that wraps the handler.
+func NewContextLogHandler(handler slog.Handler, options ...Option[*ContextLogHandlerConfig]) (*ContextLogHandler, error) {
+	cfg, err := OptionApply(&ContextLogHandlerConfig{Registry: DefaultContextKeyRegistry}, options...)
+	if err != nil {
+		return nil, err
+	}
+	return &ContextLogHandler{handler: handler, config: cfg}, nil
+}
+
+// ContextLogHandler is a slog.Handler that emits the values of logged context
+// keys on every record, hiding the values of redacted keys.
+type ContextLogHandler struct {
+	// handler records are passed to.
+	handler slog.Handler
+	// config for the keys to emit.
+	config *ContextLogHandlerConfig
+}
+
+// Enabled reports whether the wrapped handler handles records at the level.
+//
+// Interface: slog.Handler.
+func (h *ContextLogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.handler.Enabled(ctx, level)
+}
+
+// Handle adds the logged context values to the record and passes it on.
+//
+// Interface: slog.Handler.
+func (h *ContextLogHandler) Handle(ctx context.Context, record slog.Record) error {
+	var attrs []slog.Attr
+	for _, v := range h.config.Registry.Values(ctx) {
+		if !v.Config.Logged && !v.Config.Redacted {
+			continue
+		}
+		if v.Config.Redacted {
+			attrs = append(attrs, slog.String(v.Name, ContextLogRedacted))
+			continue
+		}
+		attrs = append(attrs, slog.Any(v.Name, v.Value))
+	}
+	if len(attrs) > 0 {
+		record = record.Clone()
+		if h.config.Group != "" {
+			record.AddAttrs(slog.Attr{Key: h.config.Group, Value: slog.GroupValue(attrs...)})
+		} else {
+			record.AddAttrs(attrs...)
+		}
+	}
+	return h.handler.Handle(ctx, record)
+}
+
+// WithAttrs returns a new handler with the attributes added to the wrapped handler.
+//
+// Interface: slog.Handler.
+func (h *ContextLogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &ContextLogHandler{handler: h.handler.WithAttrs(attrs), config: h.config}
+}
+
+// WithGroup returns a new handler with the group added to the wrapped handler.
+//
+// Interface: slog.Handler.
+func (h *ContextLogHandler) WithGroup(name string) slog.Handler {
+	return &ContextLogHandler{handler: h.handler.WithGroup(name), config: h.config}
+}
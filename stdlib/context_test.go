@@ -0,0 +1,73 @@
+package stdlib_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/ahawker/stdlibx-go/stdlib"
+	"github.com/ahawker/stdlibx-go/stdtest"
+)
+
+func TestContextKeyRegistry(t *testing.T) {
+	test := stdtest.NewTest(t)
+
+	registry := stdlib.NewContextKeyRegistry()
+	requestID := stdlib.NewContextKey("request_id", "",
+		stdlib.WithContextKeyRegistry(registry),
+		stdlib.WithContextKeyPropagated(),
+		stdlib.WithContextKeyLogged(),
+	)
+	token := stdlib.NewContextKey("token", "",
+		stdlib.WithContextKeyRegistry(registry),
+		stdlib.WithContextKeyRedacted(),
+	)
+	attempt := stdlib.NewContextKey("attempt", 0,
+		stdlib.WithContextKeyRegistry(registry),
+		stdlib.WithContextKeyPropagated(),
+	)
+	test.Equal(registry.Names(), []string{"request_id", "token", "attempt"})
+	test.True(requestID.Config().Propagated, "want propagated key")
+
+	ctx := requestID.WithValue(context.Background(), "abc")
+	ctx = token.WithValue(ctx, "secret")
+
+	values := registry.Values(ctx)
+	test.Equal(len(values), 2)
+	test.Equal(values[0].Name, "request_id")
+	test.Equal(values[0].Value, any("abc"))
+
+	// Only propagated keys present in the context are captured.
+	snap := registry.Snapshot(ctx)
+	test.Equal(snap.Len(), 1)
+	detached := stdlib.ContextRestore(context.Background(), snap)
+	test.Equal(requestID.Value(detached), "abc")
+	_, ok := token.ValueOk(detached)
+	test.False(ok, "want non-propagated key dropped")
+	_, ok = attempt.ValueOk(detached)
+	test.False(ok, "want missing key dropped")
+
+	var buf bytes.Buffer
+	handler, err := stdlib.NewContextLogHandler(
+		slog.NewTextHandler(&buf, nil),
+		stdlib.WithContextLogHandlerRegistry(registry),
+	)
+	test.OK(err)
+	slog.New(handler).InfoContext(ctx, "hello")
+	test.True(strings.Contains(buf.String(), "request_id=abc"), "want logged value in %q", buf.String())
+	test.True(strings.Contains(buf.String(), "token=[REDACTED]"), "want redacted value in %q", buf.String())
+	test.False(strings.Contains(buf.String(), "secret"), "want secret hidden in %q", buf.String())
+}
+
+func TestContextKeyUnregistered(t *testing.T) {
+	test := stdtest.NewTest(t)
+
+	// Keys are only registered when asked to.
+	names := stdlib.DefaultContextKeyRegistry.Names()
+	key := stdlib.NewContextKey("unregistered", "")
+	test.True(key.Config().Registry == nil, "want no registry")
+	test.Equal(stdlib.DefaultContextKeyRegistry.Names(), names)
+	test.Equal(len(stdlib.ContextValues(key.WithValue(context.Background(), "value"))), 0)
+}
@@ -1,9 +1,13 @@
 package stdlib
 
 import (
-	"fmt"
+	"errors"
+	"golang.org/x/exp/constraints"
+	"math"
+	"math/big"
 	"reflect"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -22,12 +26,19 @@ var ErrTypeConversionFailed = Error{
 }
 
 // ToMapAny returns the map[string]any representation of the given value and errors if it cannot.
-func ToMapAny[T any](value T) (map[string]any, error) {
+//
+// Structs, and pointers to them, are converted by field using their 'json' tags, honouring
+// renames, "-" and 'omitempty'. Fields of embedded structs are promoted and nested
+// structs, slices and maps are converted to map[string]any and []any.
+func ToMapAny[T any](value T, options ...Option[*StructMapConfig]) (map[string]any, error) {
 	switch v := any(value).(type) {
 	case map[string]any:
 		return v, nil
 	default:
 		rv := reflect.ValueOf(value)
+		for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+			rv = rv.Elem()
+		}
 		switch rv.Kind() {
 		case reflect.Map:
 			result := make(map[string]any, rv.Len())
@@ -39,19 +50,30 @@ func ToMapAny[T any](value T) (map[string]any, error) {
 				result[mapKey] = rv.MapIndex(mk).Interface()
 			}
 			return result, nil
+		case reflect.Struct:
+			cfg, err := newStructMapConfig(options)
+			if err != nil {
+				return nil, err
+			}
+			return structMapEncode(cfg, rv, 0), nil
 		default:
 			return nil, ErrTypeConversionFailed.Wrapf("value_type=%T desired_type=map[string]any", value)
 		}
 	}
 }
 
-// ToMapString returns the map[string]any representation of the given value and errors if it cannot.
-func ToMapString[T any](value T) (map[string]string, error) {
+// ToMapString returns the map[string]string representation of the given value and errors if it cannot.
+//
+// Structs are converted as with 'ToMapAny' and their field values converted with 'ToString'.
+func ToMapString[T any](value T, options ...Option[*StructMapConfig]) (map[string]string, error) {
 	switch v := any(value).(type) {
 	case map[string]string:
 		return v, nil
 	default:
 		rv := reflect.ValueOf(value)
+		for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+			rv = rv.Elem()
+		}
 		switch rv.Kind() {
 		case reflect.Map:
 			result := make(map[string]string, rv.Len())
@@ -67,6 +89,18 @@ func ToMapString[T any](value T) (map[string]string, error) {
 				result[mapKey] = mapVal
 			}
 			return result, nil
+		case reflect.Struct:
+			m, err := ToMapAny(rv.Interface(), options...)
+			if err != nil {
+				return nil, err
+			}
+			result := make(map[string]string, len(m))
+			for k, v := range m {
+				if result[k], err = ToString(v); err != nil {
+					return nil, err
+				}
+			}
+			return result, nil
 		default:
 			return nil, ErrTypeConversionFailed.Wrapf("value_type=%T desired_type=map[string]any", value)
 		}
@@ -74,102 +108,205 @@ func ToMapString[T any](value T) (map[string]string, error) {
 }
 
 // ToString returns the string representation of the given value and errors if it cannot.
+//
+// It is 'Convert[string]', so registered converters are used before 'fmt.Stringer',
+// 'encoding.TextMarshaler' and finally "%v".
 func ToString[T any](value T) (string, error) {
-	switch v := any(value).(type) {
-	case string:
-		return v, nil
-	case fmt.Stringer:
-		return v.String(), nil
-	default:
-		return fmt.Sprintf("%v", v), nil
-	}
+	return Convert[string](value)
 }
 
 // ToBool returns the bool representation of the given value and errors if it cannot.
+//
+// Registered converters are used before 'DefaultConverter.Bool', so strings must be truthy
+// ("true", "yes", "on", "1") or falsy ("false", "no", "off", "0", "") values.
 func ToBool[T any](value T) (bool, error) {
-	switch v := any(value).(type) {
-	case bool:
-		return v, nil
-	default:
-		return !IsZero[T](value), nil
-	}
+	return convertRegistered(value, DefaultConverter.Bool)
 }
 
 // ToInt returns the int representation of the given value and errors if it cannot.
+//
+// It is 'Convert[int]', so registered converters are used before 'ToNumber[int]'.
 func ToInt[T any](value T) (int, error) {
-	switch v := any(value).(type) {
-	case int:
-		return v, nil
-	case float32:
-		return int(v), nil
-	case float64:
-		return int(v), nil
-	case int32:
-		return int(v), nil
-	case int64:
-		return int(v), nil
-	case int8:
-		return int(v), nil
-	case int16:
-		return int(v), nil
-	case uint:
-		return int(v), nil
-	case uint8:
-		return int(v), nil
-	case uint16:
-		return int(v), nil
-	case string:
-		i, err := strconv.ParseInt(v, 10, 64)
-		if err != nil {
-			return 0, ErrTypeConversionFailed.Wrapf("value_type=%T desired_type=int", value).Wrap(err)
-		}
-		return int(i), nil
-	default:
-		return 0, ErrTypeConversionFailed.Wrapf("value_type=%T desired_type=int", value)
-	}
+	return Convert[int](value)
+}
+
+// ToNumber returns the numeric representation of the given value and errors if it cannot.
+//
+// Numbers of any kind are converted if the value fits the type without loss, otherwise
+// ErrPrecisionLoss is returned. Integers must be exactly representable by floats and
+// floats must have no fractional part to become integers; floats narrowed to float32
+// are rounded. Strings, including 'json.Number', are parsed as Go literals with base
+// prefixes (0x, 0o, 0b) and underscores, e.g. "0x_FF" or "1_000.5".
+//
+// Int128, Uint128 and Decimal values are converted like integers and floats. Registered
+// converters are used first.
+func ToNumber[T constraints.Integer | constraints.Float](value any) (T, error) {
+	return convertRegistered(value, toNumberOf[T])
 }
 
 // ToDuration returns the time.Duration representation of the given value and errors if it cannot.
+//
+// Registered converters are used before 'DefaultConverter.Duration', so strings may also be
+// ISO-8601 durations, e.g. "PT5M", and numbers are nanoseconds.
 func ToDuration[T any](value T) (time.Duration, error) {
-	switch v := any(value).(type) {
-	case time.Duration:
-		return v, nil
-	case string:
-		d, err := time.ParseDuration(v)
-		if err != nil {
-			return 0, ErrTypeConversionFailed.Wrapf("value_type=%T desired_type=time.Duration", value).Wrap(err)
-		}
-		return d, nil
-	default:
-		return 0, ErrTypeConversionFailed.Wrapf("value_type=%T desired_type=time.Duration", value)
-	}
+	return convertRegistered(value, DefaultConverter.Duration)
 }
 
 // ToTime returns the time.Time representation of the given value and errors if it cannot.
+//
+// Registered converters are used before 'DefaultConverter.Time', so strings are parsed with
+// the 'TimestampLayouts' and numbers are milliseconds since the unix epoch.
 func ToTime[T any](value T) (time.Time, error) {
-	switch v := any(value).(type) {
-	case time.Time:
-		return v, nil
-	case float64:
-		return unixMillisToTime(int64(v)), nil
-	case int64:
-		return unixMillisToTime(v), nil
-	case string:
-		for _, layout := range TimestampLayouts {
-			ts, err := time.Parse(layout, v)
-			if err == nil {
-				return ts, nil
+	return convertRegistered(value, DefaultConverter.Time)
+}
+
+// convertRegistered returns the value converted by a path of converters registered with
+// the DefaultConversionRegistry, or by the fallback if there is none.
+func convertRegistered[To any](value any, fallback func(any) (To, error)) (To, error) {
+	if value != nil {
+		rv := reflect.ValueOf(value)
+		to := reflect.TypeFor[To]()
+		if chain := DefaultConversionRegistry.resolveRegistered(rv.Type(), to); chain != nil {
+			out, err := chain.convert(value, rv, to)
+			if err != nil {
+				return *new(To), err
+			}
+			return out.Interface().(To), nil
+		}
+	}
+	return fallback(value)
+}
+
+// toNumberOf returns the value converted to the numeric type without registered converters.
+func toNumberOf[T constraints.Integer | constraints.Float](value any) (T, error) {
+	rv, err := toNumber(value, reflect.TypeFor[T]())
+	if err != nil {
+		return *new(T), err
+	}
+	return rv.Interface().(T), nil
+}
+
+// toNumber returns the value converted to the numeric type.
+func toNumber(value any, rt reflect.Type) (reflect.Value, error) {
+	failed := func(err error) (reflect.Value, error) {
+		return reflect.Value{}, ErrTypeConversionFailed.Wrapf("value_type=%T desired_type=%s", value, rt).Wrap(err)
+	}
+	lossy := func() (reflect.Value, error) {
+		return reflect.Value{}, ErrPrecisionLoss.Wrapf("from=%T to=%s value=%v", value, rt, value)
+	}
+
+	var (
+		bf      big.Float
+		isFloat bool
+	)
+	rv := reflect.ValueOf(value)
+	switch {
+	case !rv.IsValid():
+		return failed(nil)
+	case rv.Type() == int128Type:
+		bf.SetInt(value.(Int128).Big())
+	case rv.Type() == uint128Type:
+		bf.SetInt(value.(Uint128).Big())
+	case rv.Type() == decimalType:
+		r := value.(Decimal).Rat()
+		isFloat = !r.IsInt()
+		bf.SetPrec(128).SetRat(r)
+	case rv.CanInt():
+		bf.SetInt64(rv.Int())
+	case rv.CanUint():
+		bf.SetUint64(rv.Uint())
+	case rv.CanFloat():
+		f := rv.Float()
+		if math.IsNaN(f) {
+			if rt.Kind() == reflect.Float32 || rt.Kind() == reflect.Float64 {
+				return reflect.ValueOf(math.NaN()).Convert(rt), nil
+			}
+			return lossy()
+		}
+		bf.SetFloat64(f)
+		isFloat = true
+	case rv.Kind() == reflect.String:
+		s := strings.TrimSpace(rv.String())
+		digits, base := toNumberBase(s)
+		if i, err := strconv.ParseInt(digits, base, 64); err == nil {
+			bf.SetInt64(i)
+		} else if u, err := strconv.ParseUint(digits, base, 64); err == nil {
+			bf.SetUint64(u)
+		} else if f, err := strconv.ParseFloat(s, 64); err == nil {
+			if math.IsNaN(f) {
+				return toNumber(f, rt)
 			}
+			bf.SetFloat64(f)
+			isFloat = true
+		} else if errors.Is(err, strconv.ErrRange) {
+			return lossy()
+		} else {
+			return failed(err)
 		}
-		return time.Unix(0, 0), ErrTypeConversionFailed.Wrapf("value_type=%T desired_type=time.Time", value)
 	default:
-		return time.Unix(0, 0), ErrTypeConversionFailed.Wrapf("value_type=%T desired_type=time.Time", value)
+		return failed(nil)
+	}
+
+	result := reflect.New(rt).Elem()
+	switch rt.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if !bf.IsInt() {
+			return lossy()
+		}
+		i, acc := bf.Int64()
+		if acc != big.Exact || result.OverflowInt(i) {
+			return lossy()
+		}
+		result.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if !bf.IsInt() || bf.Sign() < 0 {
+			return lossy()
+		}
+		u, acc := bf.Uint64()
+		if acc != big.Exact || result.OverflowUint(u) {
+			return lossy()
+		}
+		result.SetUint(u)
+	case reflect.Float32:
+		f, acc := bf.Float32()
+		if (!isFloat && acc != big.Exact) || (math.IsInf(float64(f), 0) && !bf.IsInf()) {
+			return lossy()
+		}
+		result.SetFloat(float64(f))
+	case reflect.Float64:
+		f, acc := bf.Float64()
+		if (!isFloat && acc != big.Exact) || (math.IsInf(f, 0) && !bf.IsInf()) {
+			return lossy()
+		}
+		result.SetFloat(f)
+	default:
+		return failed(nil)
+	}
+	return result, nil
+}
+
+// toNumberBase returns the integer string and the base to parse it with. Only
+// explicit 0x, 0o and 0b prefixes change the base, so "010" is 10 rather than
+// octal 8. Decimal strings may separate digits with underscores, e.g. "1_000".
+func toNumberBase(s string) (string, int) {
+	digits := strings.TrimLeft(s, "+-")
+	if len(digits) > 1 && digits[0] == '0' {
+		switch digits[1] {
+		case 'x', 'X', 'o', 'O', 'b', 'B':
+			return s, 0
+		}
+	}
+	if strings.Contains(digits, "_") {
+		if digits[0] == '_' || digits[len(digits)-1] == '_' || strings.Contains(digits, "__") {
+			return s, 10
+		}
+		return strings.ReplaceAll(s, "_", ""), 10
 	}
+	return s, 10
 }
 
-// unixMillisToTime makes time.Time from milliseconds since unix epoch.
-func unixMillisToTime(msUnix int64) time.Time {
-	secs := int64(msUnix) / 1000
-	nsecs := (msUnix % secs) * int64(time.Millisecond)
-	return time.Unix(secs, nsecs).UTC()
+// toNumberBigInt parses the integer string with the base from 'toNumberBase'.
+func toNumberBigInt(s string) (*big.Int, bool) {
+	digits, base := toNumberBase(strings.TrimSpace(s))
+	return new(big.Int).SetString(digits, base)
 }
@@ -0,0 +1,453 @@
+package stdlib
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// DefaultConversionMaxHops is the default max number of conversions chained
+// to convert a value.
+var DefaultConversionMaxHops = 4
+
+// DefaultConversionRegistry is the ConversionRegistry used by 'Convert', 'DataTypeConvert'
+// and the 'To' and 'Must' conversion functions, e.g. 'ToString' and 'MustNumber'.
+var DefaultConversionRegistry = MustE(func() (*ConversionRegistry, error) { return NewConversionRegistry() })
+
+var (
+	stringerType        = reflect.TypeFor[fmt.Stringer]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+// ErrConversionRegistryConfig is returned when creating a ConversionRegistry with an invalid config.
+var ErrConversionRegistryConfig = Error{
+	Code:      "conversion_registry_config",
+	Message:   "conversion registry config is invalid",
+	Namespace: ErrorNamespaceDefault,
+}
+
+// ConversionRegistryConfig for a ConversionRegistry.
+type ConversionRegistryConfig struct {
+	// MaxHops is the max number of conversions chained to convert a value.
+	MaxHops int
+}
+
+// WithConversionMaxHops sets the max number of conversions chained to convert a value.
+func WithConversionMaxHops(hops int) Option[*ConversionRegistryConfig] {
+	return func(o *ConversionRegistryConfig) error {
+		if hops < 1 {
+			return ErrConversionRegistryConfig.Wrapf("max_hops=%d must be positive", hops)
+		}
+		o.MaxHops = hops
+		return nil
+	}
+}
+
+// NewConversionRegistry creates a new, empty *ConversionRegistry.
+func NewConversionRegistry(options ...Option[*ConversionRegistryConfig]) (*ConversionRegistry, error) {
+	cfg, err := OptionApply(&ConversionRegistryConfig{MaxHops: DefaultConversionMaxHops}, options...)
+	if err != nil {
+		return nil, err
+	}
+	return &ConversionRegistry{
+		config:     cfg,
+		converters: make(map[conversionPair]conversionFn),
+		edges:      make(map[reflect.Type][]conversionEdge),
+	}, nil
+}
+
+// ConversionRegistry converts values between types with registered converters.
+//
+// A value is converted by the first of:
+//   - a converter registered for its type, or an interface it implements, and the desired type
+//   - a builtin conversion between primitive types, e.g. 'ToNumber' or 'Converter.Time'
+//   - the 'encoding.TextUnmarshaler' of the desired type, given text, or the 'fmt.Stringer'
+//     and 'encoding.TextMarshaler' of the value, when a string is desired
+//   - the shortest path of registered converters that ends with one of the above
+//
+// Strings that cannot otherwise be converted are formatted with "%v". It is safe for concurrent use.
+type ConversionRegistry struct {
+	// config of the registry.
+	config *ConversionRegistryConfig
+	// mu guards converters, edges and interfaces.
+	mu sync.RWMutex
+	// converters by type pair.
+	converters map[conversionPair]conversionFn
+	// edges of the conversion graph by source type, in registration order.
+	edges map[reflect.Type][]conversionEdge
+	// interfaces are the interface source types, in registration order.
+	interfaces []reflect.Type
+}
+
+// RegisterConversion registers the converter from one type to another, replacing
+// any existing one. Interface source types match values that implement them.
+//
+// A nil registry registers with the DefaultConversionRegistry.
+func RegisterConversion[From any, To any](r *ConversionRegistry, fn func(From) (To, error)) {
+	if r == nil {
+		r = DefaultConversionRegistry
+	}
+	r.register(reflect.TypeFor[From](), reflect.TypeFor[To](), func(v reflect.Value) (reflect.Value, error) {
+		out, err := fn(v.Interface().(From))
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(&out).Elem(), nil
+	})
+}
+
+// Convert returns the value converted to the type with the DefaultConversionRegistry.
+func Convert[To any](value any) (To, error) {
+	return ConvertWith[To](DefaultConversionRegistry, value)
+}
+
+// ConvertWith returns the value converted to the type with the registry.
+//
+// A nil registry uses the DefaultConversionRegistry.
+func ConvertWith[To any](r *ConversionRegistry, value any) (To, error) {
+	if r == nil {
+		r = DefaultConversionRegistry
+	}
+	var out To
+	rv, err := r.convert(value, reflect.TypeFor[To]())
+	if err != nil {
+		return out, err
+	}
+	reflect.ValueOf(&out).Elem().Set(rv)
+	return out, nil
+}
+
+// Convert returns the value converted to the type.
+func (r *ConversionRegistry) Convert(value any, to reflect.Type) (any, error) {
+	rv, err := r.convert(value, to)
+	if err != nil {
+		return nil, err
+	}
+	return rv.Interface(), nil
+}
+
+// CanConvert returns true if values of one type can be converted to another,
+// not counting formatting with "%v".
+func (r *ConversionRegistry) CanConvert(from, to reflect.Type) bool {
+	return r.resolve(from, to) != nil
+}
+
+// register adds the converter to the graph, replacing any existing one.
+func (r *ConversionRegistry) register(from, to reflect.Type, fn conversionFn) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	pair := conversionPair{from: from, to: to}
+	if _, ok := r.converters[pair]; ok {
+		for i, edge := range r.edges[from] {
+			if edge.to == to {
+				r.edges[from][i].fn = fn
+			}
+		}
+	} else {
+		if _, ok := r.edges[from]; !ok && from.Kind() == reflect.Interface {
+			r.interfaces = append(r.interfaces, from)
+		}
+		r.edges[from] = append(r.edges[from], conversionEdge{to: to, fn: fn})
+	}
+	r.converters[pair] = fn
+}
+
+// convert returns the value converted to the type by the first conversion chain resolved.
+func (r *ConversionRegistry) convert(value any, to reflect.Type) (reflect.Value, error) {
+	rv := reflect.ValueOf(value)
+	if !rv.IsValid() {
+		switch to.Kind() {
+		case reflect.Pointer, reflect.Interface, reflect.Slice, reflect.Map, reflect.Func, reflect.Chan:
+			return reflect.Zero(to), nil
+		}
+	} else if chain := r.resolve(rv.Type(), to); chain != nil {
+		return chain.convert(value, rv, to)
+	}
+
+	if to.Kind() == reflect.String {
+		return reflect.ValueOf(fmt.Sprintf("%v", value)).Convert(to), nil
+	}
+	return reflect.Value{}, ErrTypeConversionFailed.Wrapf("value_type=%T desired_type=%s", value, to)
+}
+
+// resolve returns the shortest conversion chain between the types, or nil if there is none.
+func (r *ConversionRegistry) resolve(from, to reflect.Type) conversionChain {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if step := r.step(from, to); step != nil {
+		return conversionChain{step}
+	}
+	return r.path(from, to)
+}
+
+// resolveRegistered returns the shortest conversion chain between the types that
+// starts with a registered converter, or nil if there is none.
+func (r *ConversionRegistry) resolveRegistered(from, to reflect.Type) conversionChain {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if fn := r.registered(from, to); fn != nil {
+		return conversionChain{{fn: fn, registered: true}}
+	}
+	return r.path(from, to)
+}
+
+// step returns the single conversion between the types, or nil if there is none.
+func (r *ConversionRegistry) step(from, to reflect.Type) *conversionStep {
+	if from.AssignableTo(to) {
+		return &conversionStep{fn: conversionIdentity(to)}
+	}
+	if fn := r.registered(from, to); fn != nil {
+		return &conversionStep{fn: fn, registered: true}
+	}
+	if fn := conversionBuiltin(from, to); fn != nil {
+		return &conversionStep{fn: fn}
+	}
+	if fn := conversionFallback(from, to); fn != nil {
+		return &conversionStep{fn: fn}
+	}
+	return nil
+}
+
+// registered returns the converter registered for the types, or for an interface
+// implemented by the source type, or nil if there is none.
+func (r *ConversionRegistry) registered(from, to reflect.Type) conversionFn {
+	if fn, ok := r.converters[conversionPair{from: from, to: to}]; ok {
+		return fn
+	}
+	for _, iface := range r.interfaces {
+		if from.Implements(iface) {
+			if fn, ok := r.converters[conversionPair{from: iface, to: to}]; ok {
+				return fn
+			}
+		}
+	}
+	return nil
+}
+
+// path returns the shortest chain of registered converters, ending with any single
+// conversion to the desired type, or nil if there is none within the max hops.
+func (r *ConversionRegistry) path(from, to reflect.Type) conversionChain {
+	type node struct {
+		prev *node
+		step *conversionStep
+	}
+
+	seen := map[reflect.Type]bool{from: true}
+	queue := []reflect.Type{from}
+	nodes := map[reflect.Type]*node{from: nil}
+	for hops := 1; hops < r.config.MaxHops && len(queue) > 0; hops++ {
+		var next []reflect.Type
+		for _, t := range queue {
+			for _, edge := range r.edgesFrom(t) {
+				if seen[edge.to] {
+					continue
+				}
+				seen[edge.to] = true
+				n := &node{prev: nodes[t], step: &conversionStep{fn: edge.fn, registered: true}}
+				nodes[edge.to] = n
+				next = append(next, edge.to)
+
+				last := r.step(edge.to, to)
+				if last == nil {
+					continue
+				}
+				chain := conversionChain{last}
+				for ; n != nil; n = n.prev {
+					chain = append(conversionChain{n.step}, chain...)
+				}
+				return chain
+			}
+		}
+		queue = next
+	}
+	return nil
+}
+
+// edgesFrom returns the registered edges from the type and the interfaces it implements.
+func (r *ConversionRegistry) edgesFrom(from reflect.Type) []conversionEdge {
+	edges := r.edges[from]
+	for _, iface := range r.interfaces {
+		if iface != from && from.Implements(iface) {
+			edges = append(edges[:len(edges):len(edges)], r.edges[iface]...)
+		}
+	}
+	return edges
+}
+
+// conversionFn converts a value to another type.
+type conversionFn func(v reflect.Value) (reflect.Value, error)
+
+// conversionPair is the source and desired type of a converter.
+type conversionPair struct {
+	from reflect.Type
+	to   reflect.Type
+}
+
+// conversionEdge is a registered converter from a source type.
+type conversionEdge struct {
+	to reflect.Type
+	fn conversionFn
+}
+
+// conversionStep is a single conversion in a chain.
+type conversionStep struct {
+	fn conversionFn
+	// registered is true if fn is a registered converter, whose errors are wrapped.
+	registered bool
+}
+
+// conversionChain is the conversions applied in order to convert a value.
+type conversionChain []*conversionStep
+
+// convert applies the conversions in order. Errors from registered converters are
+// wrapped with ErrTypeConversionFailed; builtin errors, e.g. ErrPrecisionLoss, are not.
+func (c conversionChain) convert(value any, rv reflect.Value, to reflect.Type) (reflect.Value, error) {
+	var err error
+	for _, step := range c {
+		if rv, err = step.fn(rv); err != nil {
+			if step.registered {
+				err = ErrTypeConversionFailed.Wrapf("value_type=%T desired_type=%s", value, to).Wrap(err)
+			}
+			return reflect.Value{}, err
+		}
+	}
+	if rv.Type() != to {
+		out := reflect.New(to).Elem()
+		out.Set(rv)
+		rv = out
+	}
+	return rv, nil
+}
+
+// conversionIdentity returns the conversion of values assignable to the type.
+func conversionIdentity(to reflect.Type) conversionFn {
+	return func(v reflect.Value) (reflect.Value, error) {
+		out := reflect.New(to).Elem()
+		out.Set(v)
+		return out, nil
+	}
+}
+
+// conversionBuiltin returns the builtin conversion between primitive types, or nil if there is none.
+func conversionBuiltin(from, to reflect.Type) conversionFn {
+	fromNumber := conversionIsNumber(from) || from == int128Type || from == uint128Type || from == decimalType
+	fromString := from.Kind() == reflect.String
+	// Text of types with 'encoding.TextUnmarshaler', e.g. go-enum types, is left to the fallback.
+	fromText := fromString && !reflect.PointerTo(to).Implements(textUnmarshalerType)
+	switch {
+	case to == int128Type && fromNumber:
+		return func(v reflect.Value) (reflect.Value, error) {
+			i, err := ToInt128(v.Interface())
+			return reflect.ValueOf(i), err
+		}
+	case to == uint128Type && fromNumber:
+		return func(v reflect.Value) (reflect.Value, error) {
+			u, err := ToUint128(v.Interface())
+			return reflect.ValueOf(u), err
+		}
+	case to == decimalType && fromNumber:
+		return func(v reflect.Value) (reflect.Value, error) {
+			d, err := ToDecimal(v.Interface())
+			return reflect.ValueOf(d), err
+		}
+	case to == timeType && (fromNumber || fromString):
+		return func(v reflect.Value) (reflect.Value, error) {
+			t, err := DefaultConverter.Time(v.Interface())
+			return reflect.ValueOf(t), err
+		}
+	case to == durationType && (fromNumber || fromString):
+		return func(v reflect.Value) (reflect.Value, error) {
+			d, err := DefaultConverter.Duration(v.Interface())
+			return reflect.ValueOf(d), err
+		}
+	case to.Kind() == reflect.Bool && (conversionIsNumber(from) || fromText || from.Kind() == reflect.Bool):
+		return func(v reflect.Value) (reflect.Value, error) {
+			b, err := DefaultConverter.Bool(v.Interface())
+			return reflect.ValueOf(b).Convert(to), err
+		}
+	case conversionIsNumber(to) && (fromNumber || fromText):
+		return func(v reflect.Value) (reflect.Value, error) {
+			return toNumber(v.Interface(), to)
+		}
+	case to.Kind() == reflect.String && fromString && !conversionIsTextType(from) && !reflect.PointerTo(to).Implements(textUnmarshalerType):
+		return func(v reflect.Value) (reflect.Value, error) {
+			return v.Convert(to), nil
+		}
+	default:
+		return nil
+	}
+}
+
+// conversionFallback returns the conversion through the text interfaces of the
+// types, or nil if there is none.
+func conversionFallback(from, to reflect.Type) conversionFn {
+	switch {
+	case to.Kind() != reflect.Pointer && reflect.PointerTo(to).Implements(textUnmarshalerType) && conversionIsText(from):
+		return func(v reflect.Value) (reflect.Value, error) {
+			text, err := conversionText(v)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			out := reflect.New(to)
+			if err := out.Interface().(encoding.TextUnmarshaler).UnmarshalText(text); err != nil {
+				return reflect.Value{}, ErrTypeConversionFailed.Wrapf("value_type=%s desired_type=%s", v.Type(), to).Wrap(err)
+			}
+			return out.Elem(), nil
+		}
+	case to.Kind() == reflect.String && conversionIsTextType(from):
+		return func(v reflect.Value) (reflect.Value, error) {
+			text, err := conversionText(v)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			return reflect.ValueOf(string(text)).Convert(to), nil
+		}
+	default:
+		return nil
+	}
+}
+
+// conversionIsNumber returns true if the type is an integer or float kind.
+func conversionIsNumber(rt reflect.Type) bool {
+	switch rt.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+// conversionIsText returns true if the type has a text representation.
+func conversionIsText(rt reflect.Type) bool {
+	return rt.Kind() == reflect.String || (rt.Kind() == reflect.Slice && rt.Elem().Kind() == reflect.Uint8) || conversionIsTextType(rt)
+}
+
+// conversionIsTextType returns true if the type implements 'fmt.Stringer' or 'encoding.TextMarshaler'.
+func conversionIsTextType(rt reflect.Type) bool {
+	return rt.Implements(stringerType) || rt.Implements(textMarshalerType)
+}
+
+// conversionText returns the text representation of the value, preferring
+// 'fmt.Stringer' over 'encoding.TextMarshaler'.
+func conversionText(v reflect.Value) ([]byte, error) {
+	switch t := v.Interface().(type) {
+	case fmt.Stringer:
+		return []byte(t.String()), nil
+	case encoding.TextMarshaler:
+		text, err := t.MarshalText()
+		if err != nil {
+			return nil, ErrTypeConversionFailed.Wrapf("value_type=%T desired_type=string", t).Wrap(err)
+		}
+		return text, nil
+	}
+	if v.Kind() == reflect.String {
+		return []byte(v.String()), nil
+	}
+	return v.Bytes(), nil
+}
@@ -0,0 +1,177 @@
+package stdlib_test
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ahawker/stdlibx-go/stdlib"
+	"github.com/ahawker/stdlibx-go/stdtest"
+)
+
+type registryMoney struct {
+	Cents int64
+}
+
+type registryLabel struct {
+	Value string
+}
+
+func (l registryLabel) String() string {
+	return "label:" + l.Value
+}
+
+// registryColor is an integer enum like those generated by go-enum.
+type registryColor int
+
+func (c *registryColor) UnmarshalText(text []byte) error {
+	for i, name := range []string{"red", "green", "blue"} {
+		if string(text) == name {
+			*c = registryColor(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown color %q", text)
+}
+
+func TestConversionRegistry(t *testing.T) {
+	test := stdtest.NewTest(t)
+
+	errNegative := errors.New("negative")
+	r, err := stdlib.NewConversionRegistry()
+	test.OK(err)
+	stdlib.RegisterConversion(r, func(m registryMoney) (int64, error) {
+		if m.Cents < 0 {
+			return 0, errNegative
+		}
+		return m.Cents, nil
+	})
+	stdlib.RegisterConversion(r, func(s fmt.Stringer) (registryMoney, error) {
+		return registryMoney{Cents: int64(len(s.String()))}, nil
+	})
+
+	// Direct.
+	cents, err := stdlib.ConvertWith[int64](r, registryMoney{Cents: 150})
+	test.OK(err)
+	test.Equal(cents, int64(150))
+
+	_, err = stdlib.ConvertWith[int64](r, registryMoney{Cents: -1})
+	test.True(errors.Is(err, stdlib.ErrTypeConversionFailed), "want ErrTypeConversionFailed got %v", err)
+	test.True(errors.Is(err, errNegative), "want errNegative got %v", err)
+
+	// Multi-hop: money -> int64 -> int8 and label -> money -> int64 -> float64.
+	small, err := stdlib.ConvertWith[int8](r, registryMoney{Cents: 100})
+	test.OK(err)
+	test.Equal(small, int8(100))
+	_, err = stdlib.ConvertWith[int8](r, registryMoney{Cents: 1000})
+	test.True(errors.Is(err, stdlib.ErrPrecisionLoss), "want ErrPrecisionLoss got %v", err)
+
+	f, err := stdlib.ConvertWith[float64](r, registryLabel{Value: "abc"})
+	test.OK(err)
+	test.Equal(f, float64(len("label:abc")))
+	test.True(r.CanConvert(reflect.TypeFor[registryLabel](), reflect.TypeFor[uint16]()), "want label convertible to uint16")
+
+	_, err = stdlib.NewConversionRegistry(stdlib.WithConversionMaxHops(0))
+	test.EqualError(err, stdlib.ErrConversionRegistryConfig)
+	short, err := stdlib.NewConversionRegistry(stdlib.WithConversionMaxHops(1))
+	test.OK(err)
+	stdlib.RegisterConversion(short, func(m registryMoney) (int64, error) { return m.Cents, nil })
+	_, err = stdlib.ConvertWith[int8](short, registryMoney{Cents: 1})
+	test.True(errors.Is(err, stdlib.ErrTypeConversionFailed), "want ErrTypeConversionFailed got %v", err)
+
+	// Fallbacks: fmt.Stringer and encoding.TextUnmarshaler, e.g. go-enum types.
+	s, err := stdlib.ConvertWith[string](r, registryLabel{Value: "x"})
+	test.OK(err)
+	test.Equal(s, "label:x")
+
+	ip, err := stdlib.Convert[net.IP]("127.0.0.1")
+	test.OK(err)
+	test.True(ip.Equal(net.IPv4(127, 0, 0, 1)), "want 127.0.0.1 got %v", ip)
+
+	dt, err := stdlib.Convert[stdlib.DataType]("int8")
+	test.OK(err)
+	test.Equal(dt, stdlib.DataTypeInt8)
+	_, err = stdlib.Convert[stdlib.DataType]("int7")
+	test.True(errors.Is(err, stdlib.ErrTypeConversionFailed), "want ErrTypeConversionFailed got %v", err)
+
+	color, err := stdlib.Convert[registryColor]("green")
+	test.OK(err)
+	test.Equal(color, registryColor(1))
+	color, err = stdlib.Convert[registryColor](2)
+	test.OK(err)
+	test.Equal(color, registryColor(2))
+	_, err = stdlib.Convert[registryColor]("1")
+	test.True(errors.Is(err, stdlib.ErrTypeConversionFailed), "want ErrTypeConversionFailed got %v", err)
+
+	// Builtins.
+	n, err := stdlib.Convert[uint8]("0x10")
+	test.OK(err)
+	test.Equal(n, uint8(16))
+	i128, err := stdlib.Convert[stdlib.Int128]("010")
+	test.OK(err)
+	test.Equal(i128, stdlib.Int128From64(10))
+	test.Equal(stdlib.MustConvert[bool]("yes"), true)
+	test.Equal(stdlib.MustConvert[string](42), "42")
+	test.Equal(stdlib.MustConvert[*int](nil), (*int)(nil))
+}
+
+func TestConversionRegistryDefault(t *testing.T) {
+	test := stdtest.NewTest(t)
+
+	// Swap the default registry so the converters don't leak into other tests.
+	r, err := stdlib.NewConversionRegistry()
+	test.OK(err)
+	prev := stdlib.DefaultConversionRegistry
+	stdlib.DefaultConversionRegistry = r
+	t.Cleanup(func() { stdlib.DefaultConversionRegistry = prev })
+
+	type price struct {
+		Amount float64
+	}
+	type timeout struct {
+		Seconds int
+	}
+	type stamp struct {
+		Unix int64
+	}
+	stdlib.RegisterConversion(nil, func(p price) (float64, error) { return p.Amount, nil })
+	stdlib.RegisterConversion(nil, func(p price) (string, error) { return fmt.Sprintf("$%.2f", p.Amount), nil })
+	stdlib.RegisterConversion(nil, func(t timeout) (time.Duration, error) { return time.Duration(t.Seconds) * time.Second, nil })
+	stdlib.RegisterConversion(nil, func(s stamp) (time.Time, error) { return time.Unix(s.Unix, 0).UTC(), nil })
+
+	s, err := stdlib.ToString(price{Amount: 1.5})
+	test.OK(err)
+	test.Equal(s, "$1.50")
+
+	i, err := stdlib.ToInt(price{Amount: 3})
+	test.OK(err)
+	test.Equal(i, 3)
+
+	v, err := stdlib.DataTypeConvert(stdlib.DataTypeInt64, price{Amount: 2})
+	test.OK(err)
+	test.Equal(v, int64(2))
+	v, err = stdlib.DataTypeConvert(stdlib.DataTypeUtf8, price{Amount: 2.25})
+	test.OK(err)
+	test.Equal(v, "$2.25")
+
+	// The 'To' and 'Must' functions use the registered converters too.
+	n, err := stdlib.ToNumber[float32](price{Amount: 0.5})
+	test.OK(err)
+	test.Equal(n, float32(0.5))
+	test.Equal(stdlib.MustNumber[int](price{Amount: 4}), 4)
+	b, err := stdlib.ToBool(price{Amount: 1})
+	test.OK(err)
+	test.True(b, "want price with amount truthy")
+	test.False(stdlib.MustBool(price{}), "want zero price falsy")
+	d, err := stdlib.ToDuration(timeout{Seconds: 5})
+	test.OK(err)
+	test.Equal(d, 5*time.Second)
+	test.Equal(stdlib.MustDuration(timeout{Seconds: 1}), time.Second)
+	ts, err := stdlib.ToTime(stamp{Unix: 60})
+	test.OK(err)
+	test.Equal(ts, time.Unix(60, 0).UTC())
+	test.Equal(stdlib.MustTime(stamp{}), time.Unix(0, 0).UTC())
+}
@@ -0,0 +1,375 @@
+package stdlib
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// DefaultStructMapMaxDepth is the default max number of nested map levels converted
+// between structs and maps.
+var DefaultStructMapMaxDepth = 32
+
+// ErrStructMapMaxDepth is returned when decoding a map with values nested deeper
+// than the max depth.
+var ErrStructMapMaxDepth = Error{
+	Code:      "struct_map_max_depth",
+	Message:   "map values are nested deeper than the max depth",
+	Namespace: ErrorNamespaceDefault,
+}
+
+var (
+	timeType          = reflect.TypeFor[time.Time]()
+	durationType      = reflect.TypeFor[time.Duration]()
+	jsonMarshalerType = reflect.TypeFor[json.Marshaler]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+)
+
+// StructMapConfig for conversions between structs and maps.
+type StructMapConfig struct {
+	// MaxDepth is the max number of nested map levels. Deeper values are left
+	// as-is when converting to maps and return ErrStructMapMaxDepth when
+	// converting from them.
+	MaxDepth int
+	// Tag is the struct tag used for field names and options.
+	Tag string
+}
+
+// WithStructMapMaxDepth sets the max number of nested map levels converted.
+func WithStructMapMaxDepth(depth int) Option[*StructMapConfig] {
+	return func(o *StructMapConfig) error {
+		o.MaxDepth = depth
+		return nil
+	}
+}
+
+// WithStructMapTag sets the struct tag used for field names and options.
+func WithStructMapTag(tag string) Option[*StructMapConfig] {
+	return func(o *StructMapConfig) error {
+		o.Tag = tag
+		return nil
+	}
+}
+
+// FromMapAny returns the struct decoded from the map, converting values to
+// field types with the 'To*' conversions, e.g. 'ToNumber'.
+//
+// Fields are named by their 'json' tag. Fields of embedded structs are promoted.
+// Like encoding/json, fields of nil embedded pointers to unexported structs can't
+// be set and return ErrTypeConversionFailed.
+func FromMapAny[T any](input map[string]any, options ...Option[*StructMapConfig]) (T, error) {
+	var t T
+	cfg, err := newStructMapConfig(options)
+	if err != nil {
+		return t, err
+	}
+	rv := reflect.ValueOf(&t).Elem()
+	if err := structMapDecode(cfg, rv, input, "", 0); err != nil {
+		return t, err
+	}
+	return t, nil
+}
+
+// newStructMapConfig applies the options to the default config.
+func newStructMapConfig(options []Option[*StructMapConfig]) (*StructMapConfig, error) {
+	return OptionApply(&StructMapConfig{MaxDepth: DefaultStructMapMaxDepth, Tag: "json"}, options...)
+}
+
+// structMapEncode returns the map representation of the struct.
+func structMapEncode(cfg *StructMapConfig, rv reflect.Value, depth int) map[string]any {
+	fields := structMapFields(cfg, rv.Type())
+	result := make(map[string]any, len(fields))
+	for _, field := range fields {
+		fv, ok := structMapFieldByIndex(rv, field.index)
+		if !ok || (field.omitEmpty && structMapEmpty(fv)) {
+			continue
+		}
+		result[field.name] = structMapEncodeValue(cfg, fv, depth+1)
+	}
+	return result
+}
+
+// structMapEncodeValue returns the map tree representation of the value.
+func structMapEncodeValue(cfg *StructMapConfig, rv reflect.Value, depth int) any {
+	if !rv.IsValid() {
+		return nil
+	}
+	if depth >= cfg.MaxDepth || structMapLeaf(rv.Type()) {
+		return rv.Interface()
+	}
+
+	switch rv.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if rv.IsNil() {
+			return nil
+		}
+		return structMapEncodeValue(cfg, rv.Elem(), depth)
+	case reflect.Struct:
+		return structMapEncode(cfg, rv, depth)
+	case reflect.Slice, reflect.Array:
+		if rv.Kind() == reflect.Slice && (rv.IsNil() || rv.Type().Elem().Kind() == reflect.Uint8) {
+			return rv.Interface()
+		}
+		result := make([]any, rv.Len())
+		for i := range result {
+			result[i] = structMapEncodeValue(cfg, rv.Index(i), depth+1)
+		}
+		return result
+	case reflect.Map:
+		if rv.IsNil() {
+			return rv.Interface()
+		}
+		result := make(map[string]any, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			key, _ := ToString(iter.Key().Interface())
+			result[key] = structMapEncodeValue(cfg, iter.Value(), depth+1)
+		}
+		return result
+	default:
+		return rv.Interface()
+	}
+}
+
+// structMapDecode sets the fields of the struct from the map.
+func structMapDecode(cfg *StructMapConfig, rv reflect.Value, input map[string]any, path string, depth int) error {
+	if rv.Kind() != reflect.Struct {
+		return ErrTypeConversionFailed.Wrapf("value_type=map[string]any desired_type=%s", rv.Type())
+	}
+	for _, field := range structMapFields(cfg, rv.Type()) {
+		value, ok := input[field.name]
+		if !ok {
+			continue
+		}
+		fv, ok := structMapFieldByIndexAlloc(rv, field.index)
+		if !ok {
+			return ErrTypeConversionFailed.Wrapf(
+				"field=%s desired_type=%s reason=nil embedded pointer to unexported struct",
+				mapPathKey(path, field.name), rv.Type(),
+			)
+		}
+		if err := structMapDecodeValue(cfg, fv, value, mapPathKey(path, field.name), depth+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// structMapDecodeValue sets the value from the map tree value, converting it as needed.
+func structMapDecodeValue(cfg *StructMapConfig, rv reflect.Value, value any, path string, depth int) error {
+	if depth > cfg.MaxDepth {
+		return ErrStructMapMaxDepth.Wrapf("field=%s max_depth=%d", path, cfg.MaxDepth)
+	}
+	if value == nil {
+		rv.SetZero()
+		return nil
+	}
+
+	vv := reflect.ValueOf(value)
+	if vv.Type().AssignableTo(rv.Type()) {
+		rv.Set(vv)
+		return nil
+	}
+
+	failed := func(err error) error {
+		return ErrTypeConversionFailed.Wrapf("field=%s value_type=%T desired_type=%s", path, value, rv.Type()).Wrap(err)
+	}
+
+	switch rv.Type() {
+	case timeType:
+		t, err := ToTime(value)
+		if err != nil {
+			return failed(err)
+		}
+		rv.Set(reflect.ValueOf(t))
+		return nil
+	case durationType:
+		d, err := ToDuration(value)
+		if err != nil {
+			return failed(err)
+		}
+		rv.Set(reflect.ValueOf(d))
+		return nil
+	}
+
+	switch rv.Kind() {
+	case reflect.Pointer:
+		elem := reflect.New(rv.Type().Elem())
+		if err := structMapDecodeValue(cfg, elem.Elem(), value, path, depth); err != nil {
+			return err
+		}
+		rv.Set(elem)
+	case reflect.Struct:
+		m, ok := value.(map[string]any)
+		if !ok {
+			return failed(nil)
+		}
+		return structMapDecode(cfg, rv, m, path, depth)
+	case reflect.Slice:
+		if vv.Kind() != reflect.Slice && vv.Kind() != reflect.Array {
+			return failed(nil)
+		}
+		slice := reflect.MakeSlice(rv.Type(), vv.Len(), vv.Len())
+		for i := 0; i < vv.Len(); i++ {
+			if err := structMapDecodeValue(cfg, slice.Index(i), vv.Index(i).Interface(), mapPathIndex(path, i), depth+1); err != nil {
+				return err
+			}
+		}
+		rv.Set(slice)
+	case reflect.Map:
+		m, ok := value.(map[string]any)
+		if !ok || rv.Type().Key().Kind() != reflect.String {
+			return failed(nil)
+		}
+		result := reflect.MakeMapWithSize(rv.Type(), len(m))
+		for k, v := range m {
+			elem := reflect.New(rv.Type().Elem()).Elem()
+			if err := structMapDecodeValue(cfg, elem, v, mapPathKey(path, k), depth+1); err != nil {
+				return err
+			}
+			result.SetMapIndex(reflect.ValueOf(k).Convert(rv.Type().Key()), elem)
+		}
+		rv.Set(result)
+	case reflect.String:
+		s, err := ToString(value)
+		if err != nil {
+			return failed(err)
+		}
+		rv.SetString(s)
+	case reflect.Bool:
+		b, err := ToBool(value)
+		if err != nil {
+			return failed(err)
+		}
+		rv.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		n, err := toNumber(value, rv.Type())
+		if err != nil {
+			return failed(err)
+		}
+		rv.Set(n)
+	default:
+		if !vv.Type().ConvertibleTo(rv.Type()) {
+			return failed(nil)
+		}
+		rv.Set(vv.Convert(rv.Type()))
+	}
+	return nil
+}
+
+// structMapField is a struct field and its options from the struct tag.
+type structMapField struct {
+	name      string
+	index     []int
+	omitEmpty bool
+}
+
+// structMapFields returns the fields of the struct type, promoting the fields
+// of embedded structs. Fields of the outer struct take precedence.
+func structMapFields(cfg *StructMapConfig, rt reflect.Type) []structMapField {
+	var (
+		fields   []structMapField
+		embedded []structMapField
+		seen     = make(map[string]bool)
+	)
+	for i := 0; i < rt.NumField(); i++ {
+		sf := rt.Field(i)
+		tag := sf.Tag.Get(cfg.Tag)
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+
+		ft := sf.Type
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		if sf.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+			for _, field := range structMapFields(cfg, ft) {
+				field.index = append([]int{i}, field.index...)
+				embedded = append(embedded, field)
+			}
+			continue
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		seen[name] = true
+		fields = append(fields, structMapField{
+			name:      name,
+			index:     []int{i},
+			omitEmpty: strings.Contains(","+opts+",", ",omitempty,"),
+		})
+	}
+	for _, field := range embedded {
+		if !seen[field.name] {
+			seen[field.name] = true
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// structMapFieldByIndex returns the nested field, reporting false if it is
+// within a nil embedded pointer.
+func structMapFieldByIndex(rv reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && rv.Kind() == reflect.Pointer {
+			if rv.IsNil() {
+				return reflect.Value{}, false
+			}
+			rv = rv.Elem()
+		}
+		rv = rv.Field(x)
+	}
+	return rv, true
+}
+
+// structMapFieldByIndexAlloc returns the nested field, allocating nil embedded pointers.
+//
+// It reports false for nil embedded pointers to unexported structs, which can't be set.
+func structMapFieldByIndexAlloc(rv reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && rv.Kind() == reflect.Pointer {
+			if rv.IsNil() {
+				if !rv.CanSet() {
+					return reflect.Value{}, false
+				}
+				rv.Set(reflect.New(rv.Type().Elem()))
+			}
+			rv = rv.Elem()
+		}
+		rv = rv.Field(x)
+	}
+	return rv, true
+}
+
+// structMapEmpty returns true if the value is omitted by 'omitempty', using the
+// rules of encoding/json: false, zero numbers, nil pointers and interfaces, and
+// empty arrays, slices, maps and strings. Structs are never empty.
+func structMapEmpty(rv reflect.Value) bool {
+	switch rv.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return rv.Len() == 0
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64,
+		reflect.Interface, reflect.Pointer:
+		return rv.IsZero()
+	default:
+		return false
+	}
+}
+
+// structMapLeaf returns true if values of the type are kept as-is, e.g. time.Time.
+func structMapLeaf(rt reflect.Type) bool {
+	return rt == timeType || rt.Implements(jsonMarshalerType) || rt.Implements(textMarshalerType)
+}
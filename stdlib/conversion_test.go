@@ -0,0 +1,277 @@
+package stdlib_test
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/ahawker/stdlibx-go/stdlib"
+	"github.com/ahawker/stdlibx-go/stdtest"
+)
+
+type convertBase struct {
+	ID      int       `json:"id"`
+	Created time.Time `json:"created"`
+}
+
+type convertAddress struct {
+	City string `json:"city"`
+}
+
+type convertUser struct {
+	convertBase
+	Name     string            `json:"name"`
+	Email    string            `json:"email,omitempty"`
+	Password string            `json:"-"`
+	Address  *convertAddress   `json:"address"`
+	Tags     []string          `json:"tags"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	Timeout  time.Duration     `json:"timeout"`
+	Active   bool
+	internal string
+}
+
+func TestToMapAnyStruct(t *testing.T) {
+	test := stdtest.NewTest(t)
+
+	user := convertUser{
+		convertBase: convertBase{ID: 1, Created: epoch},
+		Name:        "alice",
+		Password:    "secret",
+		Address:     &convertAddress{City: "Paris"},
+		Tags:        []string{"a"},
+		internal:    "x",
+	}
+	m, err := stdlib.ToMapAny(&user)
+	test.OK(err)
+	test.Equal(m, map[string]any{
+		"id":      1,
+		"created": epoch,
+		"name":    "alice",
+		"address": map[string]any{"city": "Paris"},
+		"tags":    []any{"a"},
+		"timeout": time.Duration(0),
+		"Active":  false,
+	})
+
+	m, err = stdlib.ToMapAny(user, stdlib.WithStructMapMaxDepth(1))
+	test.OK(err)
+	test.Equal(m["address"], any(&convertAddress{City: "Paris"}))
+
+	s, err := stdlib.ToMapString(user)
+	test.OK(err)
+	test.Equal(s["name"], "alice")
+	test.Equal(s["id"], "1")
+
+	_, err = stdlib.ToMapAny(1)
+	test.True(errors.Is(err, stdlib.ErrTypeConversionFailed), "want ErrTypeConversionFailed got %v", err)
+}
+
+func TestFromMapAny(t *testing.T) {
+	test := stdtest.NewTest(t)
+
+	user, err := stdlib.FromMapAny[convertUser](map[string]any{
+		"id":       "7",
+		"created":  epoch.Format(time.RFC3339),
+		"name":     "bob",
+		"password": "ignored",
+		"address":  map[string]any{"city": "Oslo"},
+		"tags":     []any{"x", "y"},
+		"labels":   map[string]any{"team": "core"},
+		"timeout":  "5s",
+		"Active":   "true",
+	})
+	test.OK(err)
+	test.Equal(user, convertUser{
+		convertBase: convertBase{ID: 7, Created: epoch},
+		Name:        "bob",
+		Address:     &convertAddress{City: "Oslo"},
+		Tags:        []string{"x", "y"},
+		Labels:      map[string]string{"team": "core"},
+		Timeout:     5 * time.Second,
+		Active:      true,
+	})
+
+	// Struct to map and back round trips.
+	m, err := stdlib.ToMapAny(user)
+	test.OK(err)
+	roundtrip, err := stdlib.FromMapAny[convertUser](m)
+	test.OK(err)
+	test.Equal(roundtrip, user)
+
+	_, err = stdlib.FromMapAny[convertUser](map[string]any{"id": "one"})
+	test.True(errors.Is(err, stdlib.ErrTypeConversionFailed), "want ErrTypeConversionFailed got %v", err)
+}
+
+type convertInner struct {
+	X int
+}
+
+type convertOuter struct {
+	*convertInner
+	Y       int            `json:"y"`
+	Empty   []string       `json:"empty,omitempty"`
+	Zero    convertAddress `json:"zero,omitempty"`
+	Pointer *int           `json:"pointer,omitempty"`
+}
+
+func TestStructMapEdgeCases(t *testing.T) {
+	type Got struct {
+		input   map[string]any
+		options []stdlib.Option[*stdlib.StructMapConfig]
+	}
+	stdtest.Table[Got, convertOuter]{
+		"pass: fields of nil embedded pointer to unexported struct are not required": {
+			Got:  Got{input: map[string]any{"y": 2}},
+			Want: convertOuter{Y: 2},
+		},
+		"fail: nil embedded pointer to unexported struct can't be set": {
+			Got:     Got{input: map[string]any{"X": 1}},
+			WantErr: stdlib.ErrTypeConversionFailed,
+		},
+		"fail: values deeper than max depth": {
+			Got: Got{
+				input:   map[string]any{"zero": map[string]any{"city": "Oslo"}},
+				options: []stdlib.Option[*stdlib.StructMapConfig]{stdlib.WithStructMapMaxDepth(1)},
+			},
+			WantErr: stdlib.ErrStructMapMaxDepth,
+		},
+	}.Run(t, func(t *stdtest.Test, tc stdtest.Testcase[Got, convertOuter]) {
+		got, err := stdlib.FromMapAny[convertOuter](tc.Got.input, tc.Got.options...)
+		if tc.WantErr != nil {
+			t.EqualError(err, tc.WantErr)
+			return
+		}
+		t.OK(err)
+		t.Equal(got, tc.Want)
+	})
+}
+
+func TestToMapAnyOmitEmpty(t *testing.T) {
+	type Want = map[string]any
+	stdtest.Table[convertOuter, Want]{
+		"pass: empty slices are omitted and zero structs kept": {
+			Got:  convertOuter{Empty: []string{}},
+			Want: Want{"y": 0, "zero": map[string]any{"city": ""}},
+		},
+		"pass: embedded pointer fields are promoted": {
+			Got:  convertOuter{convertInner: &convertInner{X: 1}, Empty: []string{"a"}},
+			Want: Want{"X": 1, "y": 0, "empty": []any{"a"}, "zero": map[string]any{"city": ""}},
+		},
+	}.Run(t, func(t *stdtest.Test, tc stdtest.Testcase[convertOuter, Want]) {
+		got, err := stdlib.ToMapAny(tc.Got)
+		t.OK(err)
+		t.Equal(got, tc.Want)
+	})
+}
+
+// convertResult captures the result of a generic conversion for table tests.
+type convertResult struct {
+	value any
+	err   error
+}
+
+func convertGot[T any](value T, err error) convertResult {
+	return convertResult{value: value, err: err}
+}
+
+func convertRun(t *stdtest.Test, tc stdtest.Testcase[convertResult, any]) {
+	if tc.WantErr != nil {
+		t.EqualError(tc.Got.err, tc.WantErr)
+		return
+	}
+	t.OK(tc.Got.err)
+	t.Equal(tc.Got.value, tc.Want)
+}
+
+func TestToNumber(t *testing.T) {
+	stdtest.Table[convertResult, any]{
+		"pass: int64 to int8 in range":         {Got: convertGot(stdlib.ToNumber[int8](int64(-128))), Want: int8(-128)},
+		"fail: int to int8 overflow":           {Got: convertGot(stdlib.ToNumber[int8](128)), WantErr: stdlib.ErrPrecisionLoss},
+		"fail: negative int to uint8":          {Got: convertGot(stdlib.ToNumber[uint8](-1)), WantErr: stdlib.ErrPrecisionLoss},
+		"pass: max uint64":                     {Got: convertGot(stdlib.ToNumber[uint64](uint64(math.MaxUint64))), Want: uint64(math.MaxUint64)},
+		"fail: max uint64 to int64":            {Got: convertGot(stdlib.ToNumber[int64](uint64(math.MaxUint64))), WantErr: stdlib.ErrPrecisionLoss},
+		"pass: max uint32":                     {Got: convertGot(stdlib.ToNumber[uint32](uint32(math.MaxUint32))), Want: uint32(math.MaxUint32)},
+		"pass: integral float to int":          {Got: convertGot(stdlib.ToNumber[int](2.0)), Want: 2},
+		"fail: fractional float to int":        {Got: convertGot(stdlib.ToNumber[int](2.5)), WantErr: stdlib.ErrPrecisionLoss},
+		"fail: infinity to int":                {Got: convertGot(stdlib.ToNumber[int](math.Inf(1))), WantErr: stdlib.ErrPrecisionLoss},
+		"fail: float beyond int64":             {Got: convertGot(stdlib.ToNumber[int64](1e19)), WantErr: stdlib.ErrPrecisionLoss},
+		"pass: int exact as float64":           {Got: convertGot(stdlib.ToNumber[float64](1 << 53)), Want: float64(1 << 53)},
+		"fail: int inexact as float64":         {Got: convertGot(stdlib.ToNumber[float64](1<<53 + 1)), WantErr: stdlib.ErrPrecisionLoss},
+		"fail: float64 beyond float32":         {Got: convertGot(stdlib.ToNumber[float32](math.MaxFloat64)), WantErr: stdlib.ErrPrecisionLoss},
+		"pass: hex string":                     {Got: convertGot(stdlib.ToNumber[int]("0x_FF")), Want: 255},
+		"pass: binary string":                  {Got: convertGot(stdlib.ToNumber[int]("0b101")), Want: 5},
+		"pass: octal string with 0o prefix":    {Got: convertGot(stdlib.ToNumber[int]("0o10")), Want: 8},
+		"pass: leading zero string is decimal": {Got: convertGot(stdlib.ToNumber[int]("010")), Want: 10},
+		"pass: leading zero string with 8":     {Got: convertGot(stdlib.ToNumber[int]("08")), Want: 8},
+		"pass: negative leading zero string":   {Got: convertGot(stdlib.ToNumber[int]("-010")), Want: -10},
+		"pass: decimal string with underscore": {Got: convertGot(stdlib.ToNumber[int]("1_000")), Want: 1000},
+		"pass: exponent string":                {Got: convertGot(stdlib.ToNumber[int]("1e3")), Want: 1000},
+		"pass: max uint64 string":              {Got: convertGot(stdlib.ToNumber[uint64]("18446744073709551615")), Want: uint64(math.MaxUint64)},
+		"pass: float string with underscore":   {Got: convertGot(stdlib.ToNumber[float64]("1_000.5")), Want: 1000.5},
+		"pass: json number to float64":         {Got: convertGot(stdlib.ToNumber[float64](json.Number("0.25"))), Want: 0.25},
+		"fail: fractional json number to int":  {Got: convertGot(stdlib.ToNumber[int](json.Number("1.5"))), WantErr: stdlib.ErrPrecisionLoss},
+		"fail: string beyond uint64":           {Got: convertGot(stdlib.ToNumber[int]("99999999999999999999")), WantErr: stdlib.ErrPrecisionLoss},
+		"fail: misplaced underscore":           {Got: convertGot(stdlib.ToNumber[int]("1__0")), WantErr: stdlib.ErrTypeConversionFailed},
+		"fail: non-numeric string":             {Got: convertGot(stdlib.ToNumber[int]("abc")), WantErr: stdlib.ErrTypeConversionFailed},
+		"fail: bool":                           {Got: convertGot(stdlib.ToNumber[int](true)), WantErr: stdlib.ErrTypeConversionFailed},
+		"pass: ToInt from uint32":              {Got: convertGot(stdlib.ToInt(uint32(7))), Want: 7},
+		"pass: ToInt leading zero string":      {Got: convertGot(stdlib.ToInt("010")), Want: 10},
+		"fail: ToInt from fractional float":    {Got: convertGot(stdlib.ToInt(1.5)), WantErr: stdlib.ErrPrecisionLoss},
+		"pass: ToTime millis under one second": {Got: convertGot(stdlib.ToTime(int64(999))), Want: time.UnixMilli(999).UTC()},
+		"pass: ToTime float millis":            {Got: convertGot(stdlib.ToTime(float64(epoch.UnixMilli()))), Want: epoch},
+	}.Run(t, convertRun)
+}
+
+func TestConverter(t *testing.T) {
+	test := stdtest.NewTest(t)
+
+	conv, err := stdlib.NewConverter(
+		stdlib.WithConverterTruthy("enabled"),
+		stdlib.WithConverterFalsy("disabled"),
+		stdlib.WithConverterDurationUnit(time.Second),
+		stdlib.WithConverterLayouts(time.DateOnly),
+		stdlib.WithConverterLocation(time.FixedZone("UTC+2", 2*60*60)),
+		stdlib.WithConverterEpochDetection(),
+	)
+	test.OK(err)
+	loc := conv.Config().Location
+	epoch := time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC).In(loc)
+
+	stdtest.Table[convertResult, any]{
+		"pass: ToBool false string":             {Got: convertGot(stdlib.ToBool("false")), Want: false},
+		"pass: ToBool padded mixed case":        {Got: convertGot(stdlib.ToBool(" Yes ")), Want: true},
+		"pass: ToBool off":                      {Got: convertGot(stdlib.ToBool("off")), Want: false},
+		"pass: ToBool empty string":             {Got: convertGot(stdlib.ToBool("")), Want: false},
+		"pass: ToBool non-zero number":          {Got: convertGot(stdlib.ToBool(2)), Want: true},
+		"pass: ToBool zero json number":         {Got: convertGot(stdlib.ToBool(json.Number("0"))), Want: false},
+		"fail: ToBool unknown string":           {Got: convertGot(stdlib.ToBool("maybe")), WantErr: stdlib.ErrTypeConversionFailed},
+		"pass: ToDuration ISO-8601":             {Got: convertGot(stdlib.ToDuration("PT5M")), Want: 5 * time.Minute},
+		"pass: ToDuration negative ISO-8601":    {Got: convertGot(stdlib.ToDuration("-P1DT1.5S")), Want: -(24*time.Hour + 1500*time.Millisecond)},
+		"pass: ToDuration Go duration":          {Got: convertGot(stdlib.ToDuration("1m30s")), Want: 90 * time.Second},
+		"pass: ToDuration nanoseconds":          {Got: convertGot(stdlib.ToDuration(int64(time.Second))), Want: time.Second},
+		"fail: ToDuration fractional nanos":     {Got: convertGot(stdlib.ToDuration(1.5)), WantErr: stdlib.ErrPrecisionLoss},
+		"fail: ToDuration ISO-8601 months":      {Got: convertGot(stdlib.ToDuration("P1M")), WantErr: stdlib.ErrTypeConversionFailed},
+		"fail: ToDuration empty ISO-8601":       {Got: convertGot(stdlib.ToDuration("PT")), WantErr: stdlib.ErrTypeConversionFailed},
+		"pass: Bool custom truthy":              {Got: convertGot(conv.Bool("ENABLED")), Want: true},
+		"pass: Bool custom falsy":               {Got: convertGot(conv.Bool("disabled")), Want: false},
+		"pass: Duration number string in unit":  {Got: convertGot(conv.Duration("30")), Want: 30 * time.Second},
+		"pass: Duration fractional unit":        {Got: convertGot(conv.Duration(1.5)), Want: 1500 * time.Millisecond},
+		"fail: Duration overflow":               {Got: convertGot(conv.Duration(math.MaxInt64)), WantErr: stdlib.ErrPrecisionLoss},
+		"pass: Time layout in location":         {Got: convertGot(conv.Time("2024-03-01")), Want: time.Date(2024, 3, 1, 0, 0, 0, 0, loc)},
+		"pass: Time epoch seconds":              {Got: convertGot(conv.Time(epoch.Unix())), Want: epoch},
+		"pass: Time epoch milliseconds":         {Got: convertGot(conv.Time(epoch.UnixMilli())), Want: epoch},
+		"pass: Time epoch microseconds":         {Got: convertGot(conv.Time(epoch.UnixMicro())), Want: epoch},
+		"pass: Time epoch nanoseconds":          {Got: convertGot(conv.Time(epoch.UnixNano())), Want: epoch},
+		"pass: Time epoch seconds string":       {Got: convertGot(conv.Time("1709296200")), Want: epoch},
+		"pass: Time fractional epoch seconds":   {Got: convertGot(conv.Time(1709296200.5)), Want: epoch.Add(500 * time.Millisecond)},
+		"fail: NewConverter truthy falsy clash": {Got: convertGot(stdlib.NewConverter(stdlib.WithConverterTruthy("no"))), WantErr: stdlib.ErrConverterConfig},
+		"fail: NewConverter epoch unit":         {Got: convertGot(stdlib.NewConverter(stdlib.WithConverterEpochUnit(time.Minute))), WantErr: stdlib.ErrConverterConfig},
+	}.Run(t, convertRun)
+
+	// A failed ToNumber is not wrapped in a second ErrTypeConversionFailed.
+	_, err = stdlib.ToDuration("PT")
+	test.False(errors.Is(errors.Unwrap(err), stdlib.ErrTypeConversionFailed), "want single ErrTypeConversionFailed got %v", err)
+}
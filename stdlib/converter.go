@@ -0,0 +1,352 @@
+package stdlib
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// DefaultConverterTruthy are the strings converted to true, compared case-insensitively.
+	DefaultConverterTruthy = []string{"1", "t", "true", "y", "yes", "on"}
+	// DefaultConverterFalsy are the strings converted to false, compared case-insensitively.
+	DefaultConverterFalsy = []string{"", "0", "f", "false", "n", "no", "off"}
+	// DefaultConverterDurationUnit is the default unit of numeric durations, matching
+	// 'time.Duration' itself and its JSON encoding.
+	DefaultConverterDurationUnit = time.Nanosecond
+	// DefaultConverterEpochUnit is the default unit of numeric timestamps.
+	DefaultConverterEpochUnit = time.Millisecond
+)
+
+// ErrConverterConfig is returned when creating a Converter with an invalid config.
+var ErrConverterConfig = Error{
+	Code:      "converter_config",
+	Message:   "converter config is invalid",
+	Namespace: ErrorNamespaceDefault,
+}
+
+// DefaultConverter is the Converter used by 'ToBool', 'ToDuration' and 'ToTime'.
+var DefaultConverter = MustE(func() (*Converter, error) { return NewConverter() })
+
+// iso8601Duration matches ISO-8601 durations of weeks, days, hours, minutes and
+// seconds, e.g. "PT5M" or "-P1DT12H". Years and months have no fixed length and
+// are not supported.
+var iso8601Duration = regexp.MustCompile(`^([-+])?P(?:(\d+(?:[.,]\d+)?)W)?(?:(\d+(?:[.,]\d+)?)D)?(?:T(?:(\d+(?:[.,]\d+)?)H)?(?:(\d+(?:[.,]\d+)?)M)?(?:(\d+(?:[.,]\d+)?)S)?)?$`)
+
+// iso8601DurationUnits are the units of the iso8601Duration submatches.
+var iso8601DurationUnits = [...]time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second}
+
+// ConverterConfig for a Converter.
+type ConverterConfig struct {
+	// Truthy are the strings converted to true, compared case-insensitively.
+	Truthy []string
+	// Falsy are the strings converted to false, compared case-insensitively.
+	Falsy []string
+	// DurationUnit is the unit of numeric durations, e.g. time.Second for "30".
+	DurationUnit time.Duration
+	// Layouts are the time layouts tried in order when parsing strings.
+	Layouts []string
+	// Location is used for times parsed without a zone and for numeric timestamps.
+	Location *time.Location
+	// EpochUnit is the unit of numeric timestamps. Zero detects it by magnitude.
+	EpochUnit time.Duration
+}
+
+// WithConverterTruthy adds strings converted to true.
+func WithConverterTruthy(values ...string) Option[*ConverterConfig] {
+	return func(o *ConverterConfig) error {
+		o.Truthy = append(o.Truthy, values...)
+		return nil
+	}
+}
+
+// WithConverterFalsy adds strings converted to false.
+func WithConverterFalsy(values ...string) Option[*ConverterConfig] {
+	return func(o *ConverterConfig) error {
+		o.Falsy = append(o.Falsy, values...)
+		return nil
+	}
+}
+
+// WithConverterDurationUnit sets the unit of numeric durations.
+func WithConverterDurationUnit(unit time.Duration) Option[*ConverterConfig] {
+	return func(o *ConverterConfig) error {
+		if unit <= 0 {
+			return ErrConverterConfig.Wrapf("duration_unit=%v must be positive", unit)
+		}
+		o.DurationUnit = unit
+		return nil
+	}
+}
+
+// WithConverterLayouts adds time layouts, tried after those already set.
+func WithConverterLayouts(layouts ...string) Option[*ConverterConfig] {
+	return func(o *ConverterConfig) error {
+		o.Layouts = append(o.Layouts, layouts...)
+		return nil
+	}
+}
+
+// WithConverterLocation sets the location of times parsed without a zone and of
+// numeric timestamps.
+func WithConverterLocation(loc *time.Location) Option[*ConverterConfig] {
+	return func(o *ConverterConfig) error {
+		if loc == nil {
+			return ErrConverterConfig.Wrapf("location must not be nil")
+		}
+		o.Location = loc
+		return nil
+	}
+}
+
+// WithConverterEpochUnit sets the unit of numeric timestamps. It must be one of
+// time.Second, time.Millisecond, time.Microsecond or time.Nanosecond.
+func WithConverterEpochUnit(unit time.Duration) Option[*ConverterConfig] {
+	return func(o *ConverterConfig) error {
+		switch unit {
+		case time.Second, time.Millisecond, time.Microsecond, time.Nanosecond:
+			o.EpochUnit = unit
+			return nil
+		default:
+			return ErrConverterConfig.Wrapf("epoch_unit=%v must be s, ms, µs or ns", unit)
+		}
+	}
+}
+
+// WithConverterEpochDetection detects the unit of numeric timestamps by their
+// magnitude. Values are seconds below 1e11 (year 5138), then milliseconds below
+// 1e14, microseconds below 1e17 and nanoseconds otherwise.
+func WithConverterEpochDetection() Option[*ConverterConfig] {
+	return func(o *ConverterConfig) error {
+		o.EpochUnit = 0
+		return nil
+	}
+}
+
+// NewConverter creates a new *Converter with the given options.
+func NewConverter(options ...Option[*ConverterConfig]) (*Converter, error) {
+	cfg, err := OptionApply(&ConverterConfig{
+		Truthy:       append([]string(nil), DefaultConverterTruthy...),
+		Falsy:        append([]string(nil), DefaultConverterFalsy...),
+		DurationUnit: DefaultConverterDurationUnit,
+		Layouts:      append([]string(nil), TimestampLayouts[:]...),
+		Location:     time.UTC,
+		EpochUnit:    DefaultConverterEpochUnit,
+	}, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	bools := make(map[string]bool, len(cfg.Truthy)+len(cfg.Falsy))
+	for _, s := range cfg.Truthy {
+		bools[strings.ToLower(s)] = true
+	}
+	for _, s := range cfg.Falsy {
+		s = strings.ToLower(s)
+		if bools[s] {
+			return nil, ErrConverterConfig.Wrapf("value=%q is both truthy and falsy", s)
+		}
+		bools[s] = false
+	}
+	return &Converter{config: cfg, bools: bools}, nil
+}
+
+// Converter converts loosely typed values, e.g. from environment variables or
+// command line flags, to bools, durations and times.
+//
+// It is safe for concurrent use.
+type Converter struct {
+	// config of the converter.
+	config *ConverterConfig
+	// bools maps the lowered truthy and falsy strings to their value.
+	bools map[string]bool
+}
+
+// Config returns the config of the converter.
+func (c *Converter) Config() ConverterConfig {
+	return *c.config
+}
+
+// Bool returns the bool representation of the value and errors if it cannot.
+//
+// Strings must be one of the truthy or falsy values. Numbers are true when non-zero
+// and other values when not their zero value.
+func (c *Converter) Bool(value any) (bool, error) {
+	switch v := value.(type) {
+	case bool:
+		return v, nil
+	case nil:
+		return false, nil
+	case json.Number:
+		f, err := toNumberOf[float64](v)
+		if err != nil {
+			return false, ErrTypeConversionFailed.Wrapf("value_type=%T desired_type=bool", value).Wrap(err)
+		}
+		return f != 0, nil
+	}
+
+	rv := reflect.ValueOf(value)
+	if rv.Kind() == reflect.String {
+		b, ok := c.bools[strings.ToLower(strings.TrimSpace(rv.String()))]
+		if !ok {
+			return false, ErrTypeConversionFailed.Wrapf("value_type=%T desired_type=bool value=%q", value, rv.String())
+		}
+		return b, nil
+	}
+	return !rv.IsZero(), nil
+}
+
+// Duration returns the time.Duration representation of the value and errors if it cannot.
+//
+// Strings are parsed as Go durations ("1m30s"), numbers in the duration unit ("90")
+// or ISO-8601 durations ("PT1M30S"). Numbers are in the duration unit and error with
+// ErrPrecisionLoss if they are not a whole number of nanoseconds.
+func (c *Converter) Duration(value any) (time.Duration, error) {
+	failed := func(err error) (time.Duration, error) {
+		return 0, ErrTypeConversionFailed.Wrapf("value_type=%T desired_type=time.Duration", value).Wrap(err)
+	}
+
+	switch v := value.(type) {
+	case time.Duration:
+		return v, nil
+	case string:
+		s := strings.TrimSpace(v)
+		if d, err := time.ParseDuration(s); err == nil {
+			return d, nil
+		}
+		if d, ok := parseISO8601Duration(s); ok {
+			return d, nil
+		}
+		if _, err := toNumberOf[float64](s); err != nil {
+			return failed(nil)
+		}
+	}
+
+	if n, err := toNumberOf[int64](value); err == nil {
+		d := time.Duration(n) * c.config.DurationUnit
+		if n != 0 && d/c.config.DurationUnit != time.Duration(n) {
+			return failed(ErrPrecisionLoss.Wrapf("from=%T to=time.Duration value=%v", value, value))
+		}
+		return d, nil
+	}
+	f, err := toNumberOf[float64](value)
+	if errors.Is(err, ErrTypeConversionFailed) {
+		return failed(nil)
+	} else if err != nil {
+		return failed(err)
+	}
+	ns := f * float64(c.config.DurationUnit)
+	if ns != math.Trunc(ns) || ns >= math.MaxInt64 || ns < math.MinInt64 {
+		return failed(ErrPrecisionLoss.Wrapf("from=%T to=time.Duration value=%v", value, value))
+	}
+	return time.Duration(ns), nil
+}
+
+// Time returns the time.Time representation of the value and errors if it cannot.
+//
+// Strings are parsed with the layouts in order, in the location if they have no zone,
+// or as numbers. Numbers are timestamps since the unix epoch in the epoch unit.
+func (c *Converter) Time(value any) (time.Time, error) {
+	failed := func(err error) (time.Time, error) {
+		return time.Unix(0, 0), ErrTypeConversionFailed.Wrapf("value_type=%T desired_type=time.Time", value).Wrap(err)
+	}
+
+	switch v := value.(type) {
+	case time.Time:
+		return v, nil
+	case string:
+		s := strings.TrimSpace(v)
+		for _, layout := range c.config.Layouts {
+			if ts, err := time.ParseInLocation(layout, s, c.config.Location); err == nil {
+				return ts, nil
+			}
+		}
+		if _, err := toNumberOf[float64](s); err != nil {
+			return failed(nil)
+		}
+	}
+
+	if n, err := toNumberOf[int64](value); err == nil {
+		return c.epoch(n), nil
+	}
+	f, err := toNumberOf[float64](value)
+	if err != nil {
+		return failed(err)
+	}
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return failed(ErrPrecisionLoss.Wrapf("from=%T to=time.Time value=%v", value, value))
+	}
+	unit := c.epochUnit(math.Abs(f))
+	sec, frac := math.Modf(f / float64(time.Second/unit))
+	return time.Unix(int64(sec), int64(math.Round(frac*float64(time.Second)))).In(c.config.Location), nil
+}
+
+// epoch returns the time of the timestamp since the unix epoch.
+func (c *Converter) epoch(n int64) time.Time {
+	abs := float64(n)
+	if n < 0 {
+		abs = -abs
+	}
+	var t time.Time
+	switch c.epochUnit(abs) {
+	case time.Second:
+		t = time.Unix(n, 0)
+	case time.Millisecond:
+		t = time.UnixMilli(n)
+	case time.Microsecond:
+		t = time.UnixMicro(n)
+	default:
+		t = time.Unix(0, n)
+	}
+	return t.In(c.config.Location)
+}
+
+// epochUnit returns the configured epoch unit or detects it from the magnitude.
+func (c *Converter) epochUnit(abs float64) time.Duration {
+	switch {
+	case c.config.EpochUnit != 0:
+		return c.config.EpochUnit
+	case abs < 1e11:
+		return time.Second
+	case abs < 1e14:
+		return time.Millisecond
+	case abs < 1e17:
+		return time.Microsecond
+	default:
+		return time.Nanosecond
+	}
+}
+
+// parseISO8601Duration parses ISO-8601 durations of weeks, days, hours, minutes and seconds.
+func parseISO8601Duration(s string) (time.Duration, bool) {
+	m := iso8601Duration.FindStringSubmatch(s)
+	if m == nil || strings.HasSuffix(s, "P") || strings.HasSuffix(s, "T") {
+		return 0, false
+	}
+
+	var total float64
+	for i, unit := range iso8601DurationUnits {
+		part := m[i+2]
+		if part == "" {
+			continue
+		}
+		f, err := strconv.ParseFloat(strings.Replace(part, ",", ".", 1), 64)
+		if err != nil {
+			return 0, false
+		}
+		total += f * float64(unit)
+	}
+	if total >= math.MaxInt64 {
+		return 0, false
+	}
+	d := time.Duration(math.Round(total))
+	if m[1] == "-" {
+		d = -d
+	}
+	return d, true
+}
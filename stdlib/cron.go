@@ -0,0 +1,258 @@
+package stdlib
+
+import (
+	"math/bits"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var _ Schedule = (*CronSchedule)(nil)
+
+// ErrCronInvalid is returned when a cron expression cannot be parsed.
+var ErrCronInvalid = Error{
+	Code:      "cron_invalid",
+	Message:   "cron expression is invalid",
+	Namespace: ErrorNamespaceDefault,
+}
+
+// cronSearchLimit is how far into the future 'Next' searches for a matching
+// time before giving up, e.g. for expressions such as "0 0 30 2 *".
+const cronSearchLimit = 5
+
+var (
+	cronDescriptors = map[string]string{
+		"@yearly":   "0 0 0 1 1 *",
+		"@annually": "0 0 0 1 1 *",
+		"@monthly":  "0 0 0 1 * *",
+		"@weekly":   "0 0 0 * * 0",
+		"@daily":    "0 0 0 * * *",
+		"@midnight": "0 0 0 * * *",
+		"@hourly":   "0 0 * * * *",
+	}
+	cronMonthNames = map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}
+	cronDayNames = map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}
+)
+
+// cronField describes the bounds of a single cron expression field.
+type cronField struct {
+	name  string
+	min   int
+	max   int
+	names map[string]int
+}
+
+var (
+	cronSecond = cronField{name: "second", min: 0, max: 59}
+	cronMinute = cronField{name: "minute", min: 0, max: 59}
+	cronHour   = cronField{name: "hour", min: 0, max: 23}
+	cronDom    = cronField{name: "day_of_month", min: 1, max: 31}
+	cronMonth  = cronField{name: "month", min: 1, max: 12, names: cronMonthNames}
+	cronDow    = cronField{name: "day_of_week", min: 0, max: 7, names: cronDayNames}
+)
+
+// ParseCron parses a standard 5-field (minute, hour, day of month, month, day of week)
+// or 6-field (second prefixed) cron expression.
+//
+// Fields support '*', '?', lists (1,2,3), ranges (1-5), steps (*/5, 1-30/2) and
+// month/day names (JAN, MON). Day of week accepts both 0 and 7 for Sunday. The
+// descriptors @yearly, @annually, @monthly, @weekly, @daily, @midnight and
+// @hourly are also supported.
+//
+// When both day of month and day of week are restricted, a time matches if
+// either field matches, per standard cron semantics.
+func ParseCron(expr string) (*CronSchedule, error) {
+	spec := strings.TrimSpace(expr)
+	if d, ok := cronDescriptors[strings.ToLower(spec)]; ok {
+		spec = d
+	}
+
+	fields := strings.Fields(spec)
+	switch len(fields) {
+	case 5:
+		fields = append([]string{"0"}, fields...)
+	case 6:
+	default:
+		return nil, ErrCronInvalid.Wrapf("expr=%q expected 5 or 6 fields, got %d", expr, len(fields))
+	}
+
+	s := &CronSchedule{expr: expr}
+	var err error
+	if s.second, err = cronParseField(fields[0], cronSecond); err != nil {
+		return nil, err
+	}
+	if s.minute, err = cronParseField(fields[1], cronMinute); err != nil {
+		return nil, err
+	}
+	if s.hour, err = cronParseField(fields[2], cronHour); err != nil {
+		return nil, err
+	}
+	if s.dom, err = cronParseField(fields[3], cronDom); err != nil {
+		return nil, err
+	}
+	if s.month, err = cronParseField(fields[4], cronMonth); err != nil {
+		return nil, err
+	}
+	if s.dow, err = cronParseField(fields[5], cronDow); err != nil {
+		return nil, err
+	}
+
+	// Sunday can be represented as 0 or 7.
+	if s.dow&(1<<7) != 0 {
+		s.dow = (s.dow | 1) &^ (1 << 7)
+	}
+	s.domAny = cronIsWildcard(fields[3])
+	s.dowAny = cronIsWildcard(fields[5])
+	return s, nil
+}
+
+// CronSchedule is a Schedule defined by a parsed cron expression.
+type CronSchedule struct {
+	expr   string
+	second uint64
+	minute uint64
+	hour   uint64
+	dom    uint64
+	month  uint64
+	dow    uint64
+	domAny bool
+	dowAny bool
+}
+
+// String returns the cron expression the schedule was parsed from.
+func (s *CronSchedule) String() string {
+	return s.expr
+}
+
+// Next returns the next time after t that matches the schedule, in the location of t.
+//
+// The zero time is returned if no matching time can be found.
+func (s *CronSchedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = t.Add(time.Second - time.Duration(t.Nanosecond())).Truncate(time.Second)
+	limit := t.Year() + cronSearchLimit
+
+	for t.Year() <= limit {
+		if !s.has(s.month, int(t.Month())) {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !s.matchDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !s.has(s.hour, t.Hour()) {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		if !s.has(s.minute, t.Minute()) {
+			t = t.Truncate(time.Minute).Add(time.Minute)
+			continue
+		}
+		if !s.has(s.second, t.Second()) {
+			t = t.Add(time.Second)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// matchDay returns true if the day of t matches the day of month/week fields.
+func (s *CronSchedule) matchDay(t time.Time) bool {
+	dom := s.has(s.dom, t.Day())
+	dow := s.has(s.dow, int(t.Weekday()))
+	if s.domAny || s.dowAny {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+// has returns true if the bit for value is set.
+func (s *CronSchedule) has(set uint64, value int) bool {
+	return set&(1<<uint(value)) != 0
+}
+
+// cronIsWildcard returns true if the field matches every value.
+func cronIsWildcard(field string) bool {
+	return field == "*" || field == "?"
+}
+
+// cronParseField parses a single cron field into a bitset of matching values.
+func cronParseField(field string, f cronField) (uint64, error) {
+	var set uint64
+	for _, part := range strings.Split(field, ",") {
+		bits, err := cronParsePart(part, f)
+		if err != nil {
+			return 0, err
+		}
+		set |= bits
+	}
+	return set, nil
+}
+
+// cronParsePart parses a single list item of a cron field: '*', 'N', 'N-M', with optional '/step'.
+func cronParsePart(part string, f cronField) (uint64, error) {
+	rangeExpr, stepExpr, hasStep := strings.Cut(part, "/")
+
+	step := 1
+	if hasStep {
+		n, err := strconv.Atoi(stepExpr)
+		if err != nil || n <= 0 {
+			return 0, ErrCronInvalid.Wrapf("field=%s part=%q invalid step", f.name, part)
+		}
+		step = n
+	}
+
+	var start, end int
+	switch {
+	case cronIsWildcard(rangeExpr):
+		start, end = f.min, f.max
+	default:
+		lo, hi, isRange := strings.Cut(rangeExpr, "-")
+		var err error
+		if start, err = cronParseValue(lo, f); err != nil {
+			return 0, err
+		}
+		end = start
+		if isRange {
+			if end, err = cronParseValue(hi, f); err != nil {
+				return 0, err
+			}
+		} else if hasStep {
+			end = f.max
+		}
+	}
+	if start > end {
+		return 0, ErrCronInvalid.Wrapf("field=%s part=%q range start after end", f.name, part)
+	}
+
+	var set uint64
+	for i := start; i <= end; i += step {
+		set |= 1 << uint(i)
+	}
+	if bits.OnesCount64(set) == 0 {
+		return 0, ErrCronInvalid.Wrapf("field=%s part=%q matches no values", f.name, part)
+	}
+	return set, nil
+}
+
+// cronParseValue parses a single numeric or named value of a cron field.
+func cronParseValue(value string, f cronField) (int, error) {
+	if n, ok := f.names[strings.ToLower(value)]; ok {
+		return n, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, ErrCronInvalid.Wrapf("field=%s value=%q: %v", f.name, value, err)
+	}
+	if n < f.min || n > f.max {
+		return 0, ErrCronInvalid.Wrapf("field=%s value=%d out of range [%d, %d]", f.name, n, f.min, f.max)
+	}
+	return n, nil
+}
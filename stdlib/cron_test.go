@@ -0,0 +1,72 @@
+package stdlib_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ahawker/stdlibx-go/stdlib"
+	"github.com/ahawker/stdlibx-go/stdtest"
+)
+
+func TestParseCronNext(t *testing.T) {
+	type Got struct {
+		expr string
+		from time.Time
+	}
+	from := time.Date(2024, time.January, 15, 10, 30, 15, 0, time.UTC)
+	stdtest.Table[Got, time.Time]{
+		"pass: every minute": {
+			Got:  Got{expr: "* * * * *", from: from},
+			Want: time.Date(2024, time.January, 15, 10, 31, 0, 0, time.UTC),
+		},
+		"pass: every 15 seconds with seconds field": {
+			Got:  Got{expr: "*/15 * * * * *", from: from},
+			Want: time.Date(2024, time.January, 15, 10, 30, 30, 0, time.UTC),
+		},
+		"pass: hour range with step": {
+			Got:  Got{expr: "0 9-17/4 * * *", from: from},
+			Want: time.Date(2024, time.January, 15, 13, 0, 0, 0, time.UTC),
+		},
+		"pass: month and day names": {
+			Got:  Got{expr: "0 0 1 MAR MON", from: from},
+			Want: time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC),
+		},
+		"pass: day of week seven is sunday": {
+			Got:  Got{expr: "0 12 * * 7", from: from},
+			Want: time.Date(2024, time.January, 21, 12, 0, 0, 0, time.UTC),
+		},
+		"pass: leap day": {
+			Got:  Got{expr: "0 0 29 2 *", from: from},
+			Want: time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC),
+		},
+		"pass: descriptor": {
+			Got:  Got{expr: "@monthly", from: from},
+			Want: time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC),
+		},
+		"pass: impossible date returns zero time": {
+			Got:  Got{expr: "0 0 30 2 *", from: from},
+			Want: time.Time{},
+		},
+		"fail: too few fields": {
+			Got:     Got{expr: "* * *"},
+			WantErr: stdlib.ErrCronInvalid,
+		},
+		"fail: value out of range": {
+			Got:     Got{expr: "61 * * * *"},
+			WantErr: stdlib.ErrCronInvalid,
+		},
+		"fail: invalid step": {
+			Got:     Got{expr: "*/0 * * * *"},
+			WantErr: stdlib.ErrCronInvalid,
+		},
+	}.Run(t, func(t *stdtest.Test, tc stdtest.Testcase[Got, time.Time]) {
+		schedule, err := stdlib.ParseCron(tc.Got.expr)
+		if tc.WantErr != nil {
+			t.NotOK(err)
+			t.EqualError(err, tc.WantErr)
+			return
+		}
+		t.OK(err)
+		t.Equal(schedule.Next(tc.Got.from), tc.Want)
+	})
+}
@@ -3,12 +3,18 @@ package stdlib
 
 import (
 	"bytes"
+	"cmp"
 	"encoding/binary"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"golang.org/x/exp/constraints"
 	"math"
+	"math/big"
 	"reflect"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -29,13 +35,16 @@ uint64,
 uint128,
 float32,
 float64,
+decimal,
 bytes,
 utf8,
 date,
 timestamp,
 timestamp_s,
 timestamp_ms,
-list
+list,
+map,
+struct
 ).
 */
 type DataType string
@@ -52,12 +61,135 @@ var ErrConversionNotSupported = Error{
 	Namespace: "com.github.ahawker.stdlib",
 }
 
+// dataTypeReflectTypes are the Go types of the data types converted by DataTypeConvert.
+var dataTypeReflectTypes = map[DataType]reflect.Type{
+	DataTypeBool:        reflect.TypeFor[bool](),
+	DataTypeInt8:        reflect.TypeFor[int8](),
+	DataTypeInt16:       reflect.TypeFor[int16](),
+	DataTypeInt32:       reflect.TypeFor[int32](),
+	DataTypeInt64:       reflect.TypeFor[int64](),
+	DataTypeUint8:       reflect.TypeFor[uint8](),
+	DataTypeUint16:      reflect.TypeFor[uint16](),
+	DataTypeUint32:      reflect.TypeFor[uint32](),
+	DataTypeUint64:      reflect.TypeFor[uint64](),
+	DataTypeInt128:      int128Type,
+	DataTypeUint128:     uint128Type,
+	DataTypeFloat32:     reflect.TypeFor[float32](),
+	DataTypeFloat64:     reflect.TypeFor[float64](),
+	DataTypeDecimal:     decimalType,
+	DataTypeBytes:       bytesType,
+	DataTypeUtf8:        reflect.TypeFor[string](),
+	DataTypeDate:        timeType,
+	DataTypeTimestamp:   timeType,
+	DataTypeTimestampS:  timeType,
+	DataTypeTimestampMs: timeType,
+	DataTypeList:        listTypeType,
+	DataTypeMap:         mapTypeType,
+	DataTypeStruct:      structTypeType,
+}
+
+var (
+	int128Type     = reflect.TypeFor[Int128]()
+	uint128Type    = reflect.TypeFor[Uint128]()
+	decimalType    = reflect.TypeFor[Decimal]()
+	bytesType      = reflect.TypeFor[[]byte]()
+	listTypeType   = reflect.TypeFor[*ListType]()
+	mapTypeType    = reflect.TypeFor[*MapType]()
+	structTypeType = reflect.TypeFor[*StructType]()
+)
+
+// Schema is the recursive description of a data type, e.g. list<list<int32>>,
+// struct<a: list<utf8>> or decimal(10,2), as used by Parquet and Arrow columns.
+//
+// Item types of lists, key and value types of maps and field types of structs are
+// null when they are unknown, e.g. for empty lists of interface items. Precision is
+// zero when the precision and scale of a decimal are unknown.
+type Schema struct {
+	Type      DataType
+	Item      *Schema
+	Key       *Schema
+	Value     *Schema
+	Fields    []StructField
+	Precision int
+	Scale     int
+}
+
+// String returns the schema as a type expression, e.g. map<utf8, list<decimal(10,2)>>.
+func (s *Schema) String() string {
+	if s == nil {
+		return string(DataTypeNull)
+	}
+	switch s.Type {
+	case DataTypeList:
+		return fmt.Sprintf("list<%s>", s.Item)
+	case DataTypeMap:
+		return fmt.Sprintf("map<%s, %s>", s.Key, s.Value)
+	case DataTypeStruct:
+		fields := make([]string, len(s.Fields))
+		for i, field := range s.Fields {
+			fields[i] = fmt.Sprintf("%s: %s", field.Name, field.Type)
+		}
+		return fmt.Sprintf("struct<%s>", strings.Join(fields, ", "))
+	case DataTypeDecimal:
+		if s.Precision > 0 {
+			return fmt.Sprintf("decimal(%d,%d)", s.Precision, s.Scale)
+		}
+	}
+	return string(s.Type)
+}
+
+// ListType is a list of items of the same data type. Items of nested lists, maps
+// and structs are *ListType, *MapType and *StructType.
 type ListType struct {
-	ItemType DataType
+	ItemType *Schema
 	Items    []any
 }
 
+// MapType is a map of keys and values of the same data types, sorted by key.
+type MapType struct {
+	KeyType   *Schema
+	ValueType *Schema
+	Entries   []Pair[any, any]
+}
+
+// StructField is the schema of a StructType field.
+type StructField struct {
+	Name string
+	Type *Schema
+}
+
+// StructType is a record of named fields, each with its own data type.
+type StructType struct {
+	Fields []StructField
+	Values []any
+}
+
+// Get returns the value of the named field and reports whether it was present.
+func (s *StructType) Get(name string) (any, bool) {
+	for i, field := range s.Fields {
+		if field.Name == name {
+			return s.Values[i], true
+		}
+	}
+	return nil, false
+}
+
+// DataTypeConvert returns the value converted to the data type.
+//
+// Values with a path of converters registered with the DefaultConversionRegistry,
+// e.g. custom domain types, are first converted to the Go type of the data type.
 func DataTypeConvert(dt DataType, value any) (any, error) {
+	if rt, ok := dataTypeReflectTypes[dt]; ok && value != nil {
+		rv := reflect.ValueOf(value)
+		if chain := DefaultConversionRegistry.resolveRegistered(rv.Type(), rt); chain != nil {
+			converted, err := chain.convert(value, rv, rt)
+			if err != nil {
+				return nil, err
+			}
+			value = converted.Interface()
+		}
+	}
+
 	switch dt {
 	case DataTypeNull:
 		return Null(value)
@@ -79,10 +211,16 @@ func DataTypeConvert(dt DataType, value any) (any, error) {
 		return Uint32(value)
 	case DataTypeUint64:
 		return Uint64(value)
+	case DataTypeInt128:
+		return ToInt128(value)
+	case DataTypeUint128:
+		return ToUint128(value)
 	case DataTypeFloat32:
 		return Float32(value)
 	case DataTypeFloat64:
 		return Float64(value)
+	case DataTypeDecimal:
+		return ToDecimal(value)
 	case DataTypeBytes:
 		return Bytes(value)
 	case DataTypeUtf8:
@@ -97,12 +235,35 @@ func DataTypeConvert(dt DataType, value any) (any, error) {
 		return TimestampMs(value)
 	case DataTypeList:
 		return List(value)
+	case DataTypeMap:
+		return Map(value)
+	case DataTypeStruct:
+		return Struct(value)
 	default:
 		return nil, ErrConversionNotSupported.Wrapf("data_type=%s, value_type=%T, value=%v", dt, value, value)
 	}
 }
 
 func ReflectTypeToDataType(rt reflect.Type) (DataType, error) {
+	switch rt {
+	case int128Type:
+		return DataTypeInt128, nil
+	case uint128Type:
+		return DataTypeUint128, nil
+	case decimalType:
+		return DataTypeDecimal, nil
+	case timeType:
+		return DataTypeTimestamp, nil
+	case bytesType:
+		return DataTypeBytes, nil
+	case listTypeType:
+		return DataTypeList, nil
+	case mapTypeType:
+		return DataTypeMap, nil
+	case structTypeType:
+		return DataTypeStruct, nil
+	}
+
 	switch rt.Kind() {
 	case reflect.Bool:
 		return DataTypeBool, nil
@@ -112,9 +273,7 @@ func ReflectTypeToDataType(rt reflect.Type) (DataType, error) {
 		return DataTypeInt16, nil
 	case reflect.Int32:
 		return DataTypeInt32, nil
-	case reflect.Int:
-		return DataTypeInt32, nil
-	case reflect.Int64:
+	case reflect.Int, reflect.Int64:
 		return DataTypeInt64, nil
 	case reflect.Uint8:
 		return DataTypeUint8, nil
@@ -122,9 +281,7 @@ func ReflectTypeToDataType(rt reflect.Type) (DataType, error) {
 		return DataTypeUint16, nil
 	case reflect.Uint32:
 		return DataTypeUint32, nil
-	case reflect.Uint:
-		return DataTypeUint32, nil
-	case reflect.Uint64:
+	case reflect.Uint, reflect.Uint64:
 		return DataTypeUint64, nil
 	case reflect.Float32:
 		return DataTypeFloat32, nil
@@ -132,558 +289,190 @@ func ReflectTypeToDataType(rt reflect.Type) (DataType, error) {
 		return DataTypeFloat64, nil
 	case reflect.String:
 		return DataTypeUtf8, nil
-	case reflect.Slice:
+	case reflect.Slice, reflect.Array:
 		return DataTypeList, nil
+	case reflect.Map:
+		return DataTypeMap, nil
 	case reflect.Struct:
-		switch {
-		case rt == reflect.TypeOf(time.Time{}):
-			return DataTypeTimestamp, nil
-		default:
-			return DataTypeNull, ErrConversionNotSupported.Wrapf("reflect_type=%s", rt)
-		}
+		return DataTypeStruct, nil
+	case reflect.Pointer:
+		return ReflectTypeToDataType(rt.Elem())
 	default:
 		return DataTypeNull, ErrConversionNotSupported.Wrapf("reflect_type=%s", rt)
 	}
 }
 
-func Null(value any) (any, error) {
-	return reflect.Zero(reflect.TypeOf(value)).Interface(), nil
+// ReflectTypeToSchema returns the Schema of the Go type, describing the items of
+// slices, the keys and values of maps and the 'json' named fields of structs.
+//
+// Nested interface types, whose data types depend on their values, are null, as are
+// the contents of *ListType, *MapType and *StructType. Decimal precision and scale
+// depend on values too, so are unknown.
+func ReflectTypeToSchema(rt reflect.Type) (*Schema, error) {
+	cfg, err := newStructMapConfig(nil)
+	if err != nil {
+		return nil, err
+	}
+	return reflectTypeSchema(cfg, rt, make(map[reflect.Type]bool))
 }
 
-func Bool(value any) (bool, error) {
-	var zero bool
-
-	switch v := value.(type) {
-	case bool:
-		return v, nil
-	case int8, int16, int32, int64, int:
-		return v == 0, nil
-	case uint8, uint16, uint32, uint64, uint:
-		return v == 0, nil
-	case float32:
-		return v > math.SmallestNonzeroFloat32, nil
-	case float64:
-		return v > math.SmallestNonzeroFloat64, nil
-	case string:
-		parsed, err := strconv.ParseBool(v)
-		if err != nil {
-			return zero, ErrConversionNotSupported.Wrap(err)
+// reflectTypeSchema returns the Schema of the Go type, failing on recursive structs,
+// which have no finite schema.
+func reflectTypeSchema(cfg *StructMapConfig, rt reflect.Type, visiting map[reflect.Type]bool) (*Schema, error) {
+	dt, err := ReflectTypeToDataType(rt)
+	if err != nil {
+		return nil, err
+	}
+	schema := &Schema{Type: dt}
+	for rt.Kind() == reflect.Pointer && rt != listTypeType && rt != mapTypeType && rt != structTypeType {
+		rt = rt.Elem()
+	}
+	elem := func(rt reflect.Type) (*Schema, error) {
+		if rt.Kind() == reflect.Interface {
+			return &Schema{Type: DataTypeNull}, nil
 		}
-		return Bool(parsed)
-	default:
-		return zero, ErrConversionNotSupported.Wrapf("from=%T to=bool value=%v", value, value)
+		return reflectTypeSchema(cfg, rt, visiting)
 	}
-}
 
-func Int8(value any) (int8, error) {
-	var zero int8
-
-	switch v := value.(type) {
-	case int8:
-		return v, nil
-	case int16:
-		if v < math.MinInt8 || v > math.MaxInt8 {
-			return zero, ErrPrecisionLoss.Wrapf("from=%T to=int8 value=%v", v, v)
+	switch {
+	case rt == listTypeType:
+		schema.Item = &Schema{Type: DataTypeNull}
+	case rt == mapTypeType:
+		schema.Key, schema.Value = &Schema{Type: DataTypeNull}, &Schema{Type: DataTypeNull}
+	case dt == DataTypeList:
+		if schema.Item, err = elem(rt.Elem()); err != nil {
+			return nil, err
 		}
-		return int8(v), nil
-	case int32:
-		if v < math.MinInt8 || v > math.MaxInt8 {
-			return zero, ErrPrecisionLoss.Wrapf("from=%T to=int8 value=%v", v, v)
+	case dt == DataTypeMap:
+		if schema.Key, err = elem(rt.Key()); err != nil {
+			return nil, err
 		}
-		return int8(v), nil
-	case int:
-		if v < math.MinInt8 || v > math.MaxInt8 {
-			return zero, ErrPrecisionLoss.Wrapf("from=%T to=int8 value=%v", v, v)
+		if schema.Value, err = elem(rt.Elem()); err != nil {
+			return nil, err
 		}
-		return int8(v), nil
-	case int64:
-		if v < math.MinInt8 || v > math.MaxInt8 {
-			return zero, ErrPrecisionLoss.Wrapf("from=%T to=int8 value=%v", v, v)
-		}
-		return int8(v), nil
-	case uint8:
-		if v > math.MaxInt8 {
-			return zero, ErrPrecisionLoss.Wrapf("from=%T to=int8 value=%v", v, v)
-		}
-		return int8(v), nil
-	case uint16:
-		if v > math.MaxInt8 {
-			return zero, ErrPrecisionLoss.Wrapf("from=%T to=int8 value=%v", v, v)
-		}
-		return int8(v), nil
-	case uint32:
-		if v > math.MaxInt8 {
-			return zero, ErrPrecisionLoss.Wrapf("from=%T to=int8 value=%v", v, v)
-		}
-		return int8(v), nil
-	case uint:
-		if v > math.MaxInt8 {
-			return zero, ErrPrecisionLoss.Wrapf("from=%T to=int8 value=%v", v, v)
-		}
-		return int8(v), nil
-	case uint64:
-		if v > math.MaxInt8 {
-			return zero, ErrPrecisionLoss.Wrapf("from=%T to=int8 value=%v", v, v)
-		}
-		return int8(v), nil
-	case string:
-		parsed, err := strconv.ParseInt(v, 10, 8)
-		if err != nil {
-			return zero, ErrConversionNotSupported.Wrap(err)
+	case dt == DataTypeStruct && rt != structTypeType:
+		if visiting[rt] {
+			return nil, ErrConversionNotSupported.Wrapf("recursive reflect_type=%s", rt)
+		}
+		visiting[rt] = true
+		defer delete(visiting, rt)
+		for _, field := range structMapFields(cfg, rt) {
+			fieldSchema, err := elem(rt.FieldByIndex(field.index).Type)
+			if err != nil {
+				return nil, ErrConversionNotSupported.Wrapf("field=%s", field.name).Wrap(err)
+			}
+			schema.Fields = append(schema.Fields, StructField{Name: field.name, Type: fieldSchema})
 		}
-		return Int8(parsed)
-	default:
-		return zero, ErrConversionNotSupported.Wrapf("from=%T to=int8 value=%v", value, value)
 	}
+	return schema, nil
 }
 
-func Int16(value any) (int16, error) {
-	var zero int16
+func Null(value any) (any, error) {
+	return reflect.Zero(reflect.TypeOf(value)).Interface(), nil
+}
 
+func Bool(value any) (bool, error) {
 	switch v := value.(type) {
-	case int8:
-		return int16(v), nil
-	case int16:
+	case bool:
 		return v, nil
-	case int32:
-		if v < math.MinInt16 || v > math.MaxInt16 {
-			return zero, ErrPrecisionLoss.Wrapf("from=%T to=int16 value=%v", v, v)
-		}
-		return int16(v), nil
-	case int:
-		if v < math.MinInt16 || v > math.MaxInt16 {
-			return zero, ErrPrecisionLoss.Wrapf("from=%T to=int16 value=%v", v, v)
-		}
-		return int16(v), nil
-	case int64:
-		if v < math.MinInt16 || v > math.MaxInt16 {
-			return zero, ErrPrecisionLoss.Wrapf("from=%T to=int16 value=%v", v, v)
-		}
-		return int16(v), nil
-	case uint8:
-		return int16(v), nil
-	case uint16:
-		if v > math.MaxInt16 {
-			return zero, ErrPrecisionLoss.Wrapf("from=%T to=int16 value=%v", v, v)
-		}
-		return int16(v), nil
-	case uint32:
-		if v > math.MaxInt16 {
-			return zero, ErrPrecisionLoss.Wrapf("from=%T to=int16 value=%v", v, v)
-		}
-		return int16(v), nil
-	case uint:
-		if v > math.MaxInt16 {
-			return zero, ErrPrecisionLoss.Wrapf("from=%T to=int16 value=%v", v, v)
-		}
-		return int16(v), nil
-	case uint64:
-		if v > math.MaxInt16 {
-			return zero, ErrPrecisionLoss.Wrapf("from=%T to=int16 value=%v", v, v)
-		}
-		return int16(v), nil
 	case string:
-		parsed, err := strconv.ParseInt(v, 10, 16)
+		parsed, err := strconv.ParseBool(v)
 		if err != nil {
-			return zero, ErrConversionNotSupported.Wrap(err)
+			return false, ErrConversionNotSupported.Wrap(err)
 		}
-		return Int16(parsed)
+		return parsed, nil
 	default:
-		return zero, ErrConversionNotSupported.Wrapf("from=%T to=int16 value=%v", value, value)
+		u, err := dataTypeNumber[uint8](value, DataTypeBool)
+		if err != nil {
+			return false, err
+		}
+		if u > 1 {
+			return false, ErrPrecisionLoss.Wrapf("from=%T to=bool value=%v", value, value)
+		}
+		return u == 1, nil
 	}
 }
 
-func Int32(value any) (int32, error) {
-	var zero int32
+func Int8(value any) (int8, error) {
+	return dataTypeNumber[int8](value, DataTypeInt8)
+}
 
-	switch v := value.(type) {
-	case int8:
-		return int32(v), nil
-	case int16:
-		return int32(v), nil
-	case int32:
-		return v, nil
-	case int:
-		if v < math.MinInt32 || v > math.MaxInt32 {
-			return zero, ErrPrecisionLoss.Wrapf("from=%T to=int32 value=%v", v, v)
-		}
-		return int32(v), nil
-	case int64:
-		if v < math.MinInt32 || v > math.MaxInt32 {
-			return zero, ErrPrecisionLoss.Wrapf("from=%T to=int32 value=%v", v, v)
-		}
-		return int32(v), nil
-	case uint8:
-		return int32(v), nil
-	case uint16:
-		return int32(v), nil
-	case uint32:
-		if v > math.MaxInt32 {
-			return zero, ErrPrecisionLoss.Wrapf("from=%T to=int32 value=%v", v, v)
-		}
-		return int32(v), nil
-	case uint:
-		if v > math.MaxInt32 {
-			return zero, ErrPrecisionLoss.Wrapf("from=%T to=int32 value=%v", v, v)
-		}
-		return int32(v), nil
-	case uint64:
-		if v > math.MaxInt32 {
-			return zero, ErrPrecisionLoss.Wrapf("from=%T to=int32 value=%v", v, v)
-		}
-		return int32(v), nil
-	case string:
-		parsed, err := strconv.ParseInt(v, 10, 32)
-		if err != nil {
-			return zero, ErrConversionNotSupported.Wrap(err)
-		}
-		return Int32(parsed)
-	default:
-		return zero, ErrConversionNotSupported.Wrapf("from=%T to=int32 value=%v", value, value)
-	}
+func Int16(value any) (int16, error) {
+	return dataTypeNumber[int16](value, DataTypeInt16)
+}
+
+func Int32(value any) (int32, error) {
+	return dataTypeNumber[int32](value, DataTypeInt32)
 }
 
 func Int64(value any) (int64, error) {
-	var zero int64
+	return dataTypeNumber[int64](value, DataTypeInt64)
+}
 
-	switch v := value.(type) {
-	case int8:
-		return int64(v), nil
-	case int16:
-		return int64(v), nil
-	case int32:
-		return int64(v), nil
-	case int:
-		return int64(v), nil
-	case int64:
-		return v, nil
-	case uint8:
-		return int64(v), nil
-	case uint16:
-		return int64(v), nil
-	case uint32:
-		return int64(v), nil
-	case uint:
-		return int64(v), nil
-	case uint64:
-		if v > math.MaxInt64 {
-			return zero, ErrPrecisionLoss.Wrapf("from=%T to=int64 value=%v", v, v)
-		}
-		return int64(v), nil
-	case string:
-		parsed, err := strconv.ParseInt(v, 10, 64)
-		if err != nil {
-			return zero, ErrConversionNotSupported.Wrap(err)
-		}
-		return Int64(parsed)
-	default:
-		return zero, ErrConversionNotSupported.Wrapf("from=%T to=int64 value=%v", value, value)
+// ToInt128 returns the Int128 of the value and ErrPrecisionLoss if it is out of range.
+func ToInt128(value any) (Int128, error) {
+	b, err := dataTypeBigInt(value, DataTypeInt128)
+	if err != nil {
+		return Int128{}, err
 	}
+	return Int128FromBig(b)
 }
 
 func Uint8(value any) (uint8, error) {
-	var zero uint8
-
-	switch v := value.(type) {
-	case int8:
-		if v < 0 {
-			return zero, ErrPrecisionLoss.Wrapf("from=%T to=uint8 value=%v", v, v)
-		}
-		return uint8(v), nil
-	case int16:
-		if v < 0 || v > math.MaxUint8 {
-			return zero, ErrPrecisionLoss.Wrapf("from=%T to=uint8 value=%v", v, v)
-		}
-		return uint8(v), nil
-	case int32:
-		if v < 0 || v > math.MaxUint8 {
-			return zero, ErrPrecisionLoss.Wrapf("from=%T to=uint8 value=%v", v, v)
-		}
-		return uint8(v), nil
-	case int:
-		if v < 0 || v > math.MaxUint8 {
-			return zero, ErrPrecisionLoss.Wrapf("from=%T to=uint8 value=%v", v, v)
-		}
-		return uint8(v), nil
-	case int64:
-		if v < 0 || v > math.MaxUint8 {
-			return zero, ErrPrecisionLoss.Wrapf("from=%T to=uint8 value=%v", v, v)
-		}
-		return uint8(v), nil
-	case uint8:
-		return v, nil
-	case uint16:
-		if v > math.MaxUint8 {
-			return zero, ErrPrecisionLoss.Wrapf("from=%T to=uint8 value=%v", v, v)
-		}
-		return uint8(v), nil
-	case uint32:
-		if v > math.MaxUint8 {
-			return zero, ErrPrecisionLoss.Wrapf("from=%T to=uint8 value=%v", v, v)
-		}
-		return uint8(v), nil
-	case uint:
-		if v > math.MaxUint8 {
-			return zero, ErrPrecisionLoss.Wrapf("from=%T to=uint8 value=%v", v, v)
-		}
-		return uint8(v), nil
-	case uint64:
-		if v > math.MaxUint8 {
-			return zero, ErrPrecisionLoss.Wrapf("from=%T to=uint8 value=%v", v, v)
-		}
-		return uint8(v), nil
-	case string:
-		parsed, err := strconv.ParseInt(v, 10, 8)
-		if err != nil {
-			return zero, ErrConversionNotSupported.Wrap(err)
-		}
-		return Uint8(parsed)
-	default:
-		return zero, ErrConversionNotSupported.Wrapf("from=%T to=uint8 value=%v", value, value)
-	}
+	return dataTypeNumber[uint8](value, DataTypeUint8)
 }
 
 func Uint16(value any) (uint16, error) {
-	var zero uint16
-
-	switch v := value.(type) {
-	case int8:
-		if v < 0 {
-			return zero, ErrPrecisionLoss.Wrapf("from=%T to=uint16 value=%v", v, v)
-		}
-		return uint16(v), nil
-	case int16:
-		if v < 0 {
-			return zero, ErrPrecisionLoss.Wrapf("from=%T to=uint16 value=%v", v, v)
-		}
-		return uint16(v), nil
-	case int32:
-		if v < 0 || v > math.MaxUint16 {
-			return zero, ErrPrecisionLoss.Wrapf("from=%T to=uint16 value=%v", v, v)
-		}
-		return uint16(v), nil
-	case int:
-		if v < 0 || v > math.MaxUint16 {
-			return zero, ErrPrecisionLoss.Wrapf("from=%T to=uint16 value=%v", v, v)
-		}
-		return uint16(v), nil
-	case int64:
-		if v < 0 || v > math.MaxUint16 {
-			return zero, ErrPrecisionLoss.Wrapf("from=%T to=uint16 value=%v", v, v)
-		}
-		return uint16(v), nil
-	case uint8:
-		return uint16(v), nil
-	case uint16:
-		return v, nil
-	case uint32:
-		if v > math.MaxUint16 {
-			return zero, ErrPrecisionLoss.Wrapf("from=%T to=uint16 value=%v", v, v)
-		}
-		return uint16(v), nil
-	case uint:
-		if v > math.MaxUint16 {
-			return zero, ErrPrecisionLoss.Wrapf("from=%T to=uint16 value=%v", v, v)
-		}
-		return uint16(v), nil
-	case uint64:
-		if v > math.MaxUint16 {
-			return zero, ErrPrecisionLoss.Wrapf("from=%T to=uint16 value=%v", v, v)
-		}
-		return uint16(v), nil
-	case string:
-		parsed, err := strconv.ParseInt(v, 10, 16)
-		if err != nil {
-			return zero, ErrConversionNotSupported.Wrap(err)
-		}
-		return Uint16(parsed)
-	default:
-		return zero, ErrConversionNotSupported.Wrapf("from=%T to=uint16 value=%v", value, value)
-	}
+	return dataTypeNumber[uint16](value, DataTypeUint16)
 }
 
 func Uint32(value any) (uint32, error) {
-	var zero uint32
-
-	switch v := value.(type) {
-	case int8:
-		if v < 0 {
-			return zero, ErrPrecisionLoss.Wrapf("from=%T to=uint32 value=%v", v, v)
-		}
-		return uint32(v), nil
-	case int16:
-		if v < 0 {
-			return zero, ErrPrecisionLoss.Wrapf("from=%T to=uint32 value=%v", v, v)
-		}
-		return uint32(v), nil
-	case int32:
-		if v < 0 {
-			return zero, ErrPrecisionLoss.Wrapf("from=%T to=uint32 value=%v", v, v)
-		}
-		return uint32(v), nil
-	case int:
-		if v < 0 || v > math.MaxInt32 {
-			return zero, ErrPrecisionLoss.Wrapf("from=%T to=uint32 value=%v", v, v)
-		}
-		return uint32(v), nil
-	case int64:
-		if v < 0 || v > math.MaxInt64 {
-			return zero, ErrPrecisionLoss.Wrapf("from=%T to=uint32 value=%v", v, v)
-		}
-		return uint32(v), nil
-	case uint8:
-		return uint32(v), nil
-	case uint16:
-		return uint32(v), nil
-	case uint32:
-		return v, nil
-	case uint:
-		return uint32(v), nil
-	case uint64:
-		if v > math.MaxUint32 {
-			return zero, ErrPrecisionLoss.Wrapf("from=%T to=uint32 value=%v", v, v)
-		}
-		return uint32(v), nil
-	case string:
-		parsed, err := strconv.ParseInt(v, 10, 32)
-		if err != nil {
-			return zero, ErrConversionNotSupported.Wrap(err)
-		}
-		return Uint32(parsed)
-	default:
-		return zero, ErrConversionNotSupported.Wrapf("from=%T to=uint32 value=%v", value, value)
-	}
+	return dataTypeNumber[uint32](value, DataTypeUint32)
 }
 
 func Uint64(value any) (uint64, error) {
-	var zero uint64
+	return dataTypeNumber[uint64](value, DataTypeUint64)
+}
 
-	switch v := value.(type) {
-	case int8:
-		if v < 0 {
-			return zero, ErrPrecisionLoss.Wrapf("from=%T to=uint64 value=%v", v, v)
-		}
-		return uint64(v), nil
-	case int16:
-		if v < 0 {
-			return zero, ErrPrecisionLoss.Wrapf("from=%T to=uint64 value=%v", v, v)
-		}
-		return uint64(v), nil
-	case int32:
-		if v < 0 {
-			return zero, ErrPrecisionLoss.Wrapf("from=%T to=uint64 value=%v", v, v)
-		}
-		return uint64(v), nil
-	case int:
-		if v < 0 {
-			return zero, ErrPrecisionLoss.Wrapf("from=%T to=uint64 value=%v", v, v)
-		}
-		return uint64(v), nil
-	case int64:
-		if v < 0 {
-			return zero, ErrPrecisionLoss.Wrapf("from=%T to=uint64 value=%v", v, v)
-		}
-		return uint64(v), nil
-	case uint8:
-		return uint64(v), nil
-	case uint16:
-		return uint64(v), nil
-	case uint32:
-		return uint64(v), nil
-	case uint:
-		return uint64(v), nil
-	case uint64:
-		return v, nil
-	case string:
-		parsed, err := strconv.ParseInt(v, 10, 64)
-		if err != nil {
-			return zero, ErrConversionNotSupported.Wrap(err)
-		}
-		return Uint64(parsed)
-	default:
-		return zero, ErrConversionNotSupported.Wrapf("from=%T to=uint64 value=%v", value, value)
+// ToUint128 returns the Uint128 of the value and ErrPrecisionLoss if it is out of range.
+func ToUint128(value any) (Uint128, error) {
+	b, err := dataTypeBigInt(value, DataTypeUint128)
+	if err != nil {
+		return Uint128{}, err
 	}
+	return Uint128FromBig(b)
 }
 
 func Float32(value any) (float32, error) {
-	var zero float32
-
-	switch v := value.(type) {
-	case int8:
-		return float32(v), nil
-	case int16:
-		return float32(v), nil
-	case int32:
-		return float32(v), nil
-	case int:
-		return float32(v), nil
-	case int64:
-		if v > int64(math.Floor(math.MaxFloat32)) {
-			return zero, ErrPrecisionLoss.Wrapf("from=%T to=float32 value=%v", v, v)
-		}
-		return float32(v), nil
-	case uint8:
-		return float32(v), nil
-	case uint16:
-		return float32(v), nil
-	case uint32:
-		return float32(v), nil
-	case uint:
-		return float32(v), nil
-	case uint64:
-		if v < 0 || v > uint64(math.Floor(math.MaxFloat32)) {
-			return zero, ErrPrecisionLoss.Wrapf("from=%T to=float32 value=%v", v, v)
-		}
-		return float32(v), nil
-	case string:
-		parsed, err := strconv.ParseFloat(v, 32)
-		if err != nil {
-			return zero, ErrConversionNotSupported.Wrap(err)
-		}
-		return Float32(parsed)
-	default:
-		return zero, ErrConversionNotSupported.Wrapf("from=%T to=float32 value=%v", value, value)
-	}
+	return dataTypeNumber[float32](value, DataTypeFloat32)
 }
 
 func Float64(value any) (float64, error) {
-	var zero float64
+	return dataTypeNumber[float64](value, DataTypeFloat64)
+}
 
+// ToDecimal returns the Decimal of the value and ErrPrecisionLoss if it has more
+// than DecimalMaxPrecision digits. Floats use their shortest representation.
+func ToDecimal(value any) (Decimal, error) {
 	switch v := value.(type) {
-	case int8:
-		return float64(v), nil
-	case int16:
-		return float64(v), nil
-	case int32:
-		return float64(v), nil
-	case int:
-		return float64(v), nil
-	case int64:
-		if v < 0 || v > int64(math.Floor(math.MaxFloat64)) {
-			return zero, ErrPrecisionLoss.Wrapf("from=%T to=float64 value=%v", v, v)
-		}
-		return float64(v), nil
-	case uint8:
-		return float64(v), nil
-	case uint16:
-		return float64(v), nil
-	case uint32:
-		return float64(v), nil
-	case uint:
-		return float64(v), nil
-	case uint64:
-		if v < 0 || v > uint64(math.Floor(math.MaxFloat64)) {
-			return zero, ErrPrecisionLoss.Wrapf("from=%T to=float64 value=%v", v, v)
-		}
-		return float64(v), nil
+	case Decimal:
+		return v, nil
+	case float32:
+		return ParseDecimal(strconv.FormatFloat(float64(v), 'g', -1, 32))
+	case float64:
+		return DecimalFromFloat64(v)
 	case string:
-		parsed, err := strconv.ParseFloat(v, 64)
-		if err != nil {
-			return 0, ErrConversionNotSupported.Wrap(err)
+		d, err := ParseDecimal(v)
+		if err != nil && !errors.Is(err, ErrPrecisionLoss) {
+			return Decimal{}, ErrConversionNotSupported.Wrap(err)
 		}
-		return Float64(parsed)
+		return d, err
 	default:
-		return zero, ErrConversionNotSupported.Wrapf("from=%T to=float64 value=%v", value, value)
+		b, err := dataTypeBigInt(value, DataTypeDecimal)
+		if err != nil {
+			return Decimal{}, err
+		}
+		return decimalFromBig(b, 0)
 	}
 }
 
@@ -852,53 +641,324 @@ func TimestampMs(value any) (time.Time, error) {
 
 func List(value any) (*ListType, error) {
 	zero := &ListType{
-		ItemType: DataTypeNull,
+		ItemType: &Schema{Type: DataTypeNull},
 		Items:    make([]any, 0),
 	}
 
 	switch v := value.(type) {
+	case *ListType:
+		return v, nil
 	case bool:
-		return &ListType{ItemType: DataTypeBool, Items: []any{v}}, nil
+		return &ListType{ItemType: &Schema{Type: DataTypeBool}, Items: []any{v}}, nil
 	case int8:
-		return &ListType{ItemType: DataTypeInt8, Items: []any{v}}, nil
+		return &ListType{ItemType: &Schema{Type: DataTypeInt8}, Items: []any{v}}, nil
 	case int16:
-		return &ListType{ItemType: DataTypeInt16, Items: []any{v}}, nil
+		return &ListType{ItemType: &Schema{Type: DataTypeInt16}, Items: []any{v}}, nil
 	case int32:
-		return &ListType{ItemType: DataTypeInt32, Items: []any{v}}, nil
+		return &ListType{ItemType: &Schema{Type: DataTypeInt32}, Items: []any{v}}, nil
 	case int:
-		return &ListType{ItemType: DataTypeInt32, Items: []any{v}}, nil
+		return &ListType{ItemType: &Schema{Type: DataTypeInt64}, Items: []any{int64(v)}}, nil
 	case int64:
-		return &ListType{ItemType: DataTypeInt32, Items: []any{v}}, nil
+		return &ListType{ItemType: &Schema{Type: DataTypeInt64}, Items: []any{v}}, nil
 	default:
 		rv := reflect.ValueOf(value)
-		if rv.Kind() != reflect.Slice {
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
 			return zero, ErrConversionNotSupported.Wrapf("from=%T to=list value=%v", value, value)
 		}
-		items := make([]any, rv.Len())
-		for i := 0; i < rv.Len(); i++ {
-			items[i] = rv.Index(i).Interface()
-		}
-		itemDataType, err := ReflectTypeToDataType(rv.Type().Elem())
+		itemType, items, err := dataTypeItems(rv.Type().Elem(), rv.Len(), rv.Index)
 		if err != nil {
 			return nil, err
 		}
 		return &ListType{
-			ItemType: itemDataType,
+			ItemType: itemType,
 			Items:    items,
 		}, nil
 	}
 }
 
-// unixMillisToTime makes time.Time from milliseconds since unix epoch.
+// Map returns the MapType of the map, converting keys and values to their data types.
+// Entries are sorted by key, comparing numbers and strings by value, as in Set.MarshalJSON,
+// and other keys by "%v".
+func Map(value any) (*MapType, error) {
+	if v, ok := value.(*MapType); ok {
+		return v, nil
+	}
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Map {
+		return nil, ErrConversionNotSupported.Wrapf("from=%T to=map value=%v", value, value)
+	}
+
+	// Entries are collected by ranging, as keys such as NaN can't be looked up.
+	pairs := make([]Pair[reflect.Value, reflect.Value], 0, rv.Len())
+	for iter := rv.MapRange(); iter.Next(); {
+		pairs = append(pairs, Pair[reflect.Value, reflect.Value]{First: iter.Key(), Second: iter.Value()})
+	}
+	slices.SortFunc(pairs, func(a, b Pair[reflect.Value, reflect.Value]) int {
+		if c, ok := reflectCompare(reflect.ValueOf(a.First.Interface()), reflect.ValueOf(b.First.Interface())); ok {
+			return c
+		}
+		return cmp.Compare(fmt.Sprint(a.First.Interface()), fmt.Sprint(b.First.Interface()))
+	})
+	keyType, convertedKeys, err := dataTypeItems(rv.Type().Key(), len(pairs), func(i int) reflect.Value { return pairs[i].First })
+	if err != nil {
+		return nil, err
+	}
+	valueType, values, err := dataTypeItems(rv.Type().Elem(), len(pairs), func(i int) reflect.Value { return pairs[i].Second })
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]Pair[any, any], len(pairs))
+	for i := range entries {
+		entries[i] = Pair[any, any]{First: convertedKeys[i], Second: values[i]}
+	}
+	return &MapType{KeyType: keyType, ValueType: valueType, Entries: entries}, nil
+}
+
+// Struct returns the StructType of the struct, or map with string keys, converting
+// field values to their data types. Struct fields are named by their 'json' tag and
+// map fields are sorted by name.
+func Struct(value any) (*StructType, error) {
+	if v, ok := value.(*StructType); ok {
+		return v, nil
+	}
+	rv := reflect.ValueOf(value)
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+
+	var (
+		names  []string
+		fields []reflect.Value
+	)
+	switch {
+	case rv.Kind() == reflect.Struct:
+		cfg, err := newStructMapConfig(nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, field := range structMapFields(cfg, rv.Type()) {
+			if fv, ok := structMapFieldByIndex(rv, field.index); ok {
+				names, fields = append(names, field.name), append(fields, fv)
+			}
+		}
+	case rv.Kind() == reflect.Map && rv.Type().Key().Kind() == reflect.String:
+		for _, key := range rv.MapKeys() {
+			names = append(names, key.String())
+		}
+		slices.Sort(names)
+		for _, name := range names {
+			fields = append(fields, rv.MapIndex(reflect.ValueOf(name).Convert(rv.Type().Key())))
+		}
+	default:
+		return nil, ErrConversionNotSupported.Wrapf("from=%T to=struct value=%v", value, value)
+	}
+
+	result := &StructType{Fields: make([]StructField, len(fields)), Values: make([]any, len(fields))}
+	for i, fv := range fields {
+		schema, values, err := dataTypeItems(fv.Type(), 1, func(int) reflect.Value { return fv })
+		if err != nil {
+			return nil, ErrConversionNotSupported.Wrapf("field=%s", names[i]).Wrap(err)
+		}
+		result.Fields[i] = StructField{Name: names[i], Type: schema}
+		result.Values[i] = values[0]
+	}
+	return result, nil
+}
+
+// dataTypeNumber returns the value converted to the numeric type with 'ToNumber',
+// wrapping errors other than ErrPrecisionLoss with ErrConversionNotSupported.
+func dataTypeNumber[T constraints.Integer | constraints.Float](value any, dt DataType) (T, error) {
+	rv, err := toNumber(value, reflect.TypeFor[T]())
+	if err != nil {
+		if errors.Is(err, ErrPrecisionLoss) {
+			return *new(T), err
+		}
+		return *new(T), ErrConversionNotSupported.Wrapf("from=%T to=%s value=%v", value, dt, value).Wrap(err)
+	}
+	return rv.Interface().(T), nil
+}
+
+// dataTypeBigInt returns the integer value as a big.Int and ErrPrecisionLoss if it
+// has a fractional part.
+func dataTypeBigInt(value any, dt DataType) (*big.Int, error) {
+	lossy := func() (*big.Int, error) {
+		return nil, ErrPrecisionLoss.Wrapf("from=%T to=%s value=%v", value, dt, value)
+	}
+
+	switch v := value.(type) {
+	case Int128:
+		return v.Big(), nil
+	case Uint128:
+		return v.Big(), nil
+	case Decimal:
+		r := v.Rat()
+		if !r.IsInt() {
+			return lossy()
+		}
+		return r.Num(), nil
+	}
+
+	rv := reflect.ValueOf(value)
+	switch {
+	case !rv.IsValid():
+		return nil, ErrConversionNotSupported.Wrapf("from=%T to=%s value=%v", value, dt, value)
+	case rv.CanInt():
+		return big.NewInt(rv.Int()), nil
+	case rv.CanUint():
+		return new(big.Int).SetUint64(rv.Uint()), nil
+	case rv.CanFloat():
+		f := rv.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return lossy()
+		}
+		bf := big.NewFloat(f)
+		if !bf.IsInt() {
+			return lossy()
+		}
+		b, _ := bf.Int(nil)
+		return b, nil
+	case rv.Kind() == reflect.String:
+		b, ok := toNumberBigInt(rv.String())
+		if !ok {
+			return nil, ErrConversionNotSupported.Wrapf("from=%T to=%s value=%v", value, dt, value)
+		}
+		return b, nil
+	default:
+		return nil, ErrConversionNotSupported.Wrapf("from=%T to=%s value=%v", value, dt, value)
+	}
+}
+
+// dataTypeItems returns the schema of the items and the items converted to its data type.
+//
+// Items of interface types must all have the same data type. Nil items are kept as nil.
+// The schema is refined by the items, e.g. with the item types of nested interface
+// lists and the precision and scale of decimals.
+func dataTypeItems(rt reflect.Type, n int, item func(i int) reflect.Value) (*Schema, []any, error) {
+	items := make([]any, n)
+	for i := range items {
+		iv := item(i)
+		for (iv.Kind() == reflect.Interface || iv.Kind() == reflect.Pointer) && !iv.IsNil() && iv.Type() != listTypeType && iv.Type() != mapTypeType && iv.Type() != structTypeType {
+			iv = iv.Elem()
+		}
+		if (iv.Kind() == reflect.Interface || iv.Kind() == reflect.Pointer) && iv.IsNil() {
+			continue
+		}
+		items[i] = iv.Interface()
+	}
+
+	schema := &Schema{Type: DataTypeNull}
+	if rt.Kind() != reflect.Interface {
+		var err error
+		if schema, err = ReflectTypeToSchema(rt); err != nil {
+			return nil, nil, err
+		}
+	} else {
+		for _, item := range items {
+			if item == nil {
+				continue
+			}
+			itemType, err := ReflectTypeToDataType(reflect.TypeOf(item))
+			if err != nil {
+				return nil, nil, err
+			}
+			if schema.Type != DataTypeNull && schema.Type != itemType {
+				return nil, nil, ErrConversionNotSupported.Wrapf("mixed item data types %s and %s", schema.Type, itemType)
+			}
+			schema = &Schema{Type: itemType}
+		}
+	}
+
+	for i, item := range items {
+		if item == nil {
+			continue
+		}
+		converted, err := DataTypeConvert(schema.Type, item)
+		if err != nil {
+			return nil, nil, err
+		}
+		items[i] = converted
+		if schema, err = schema.merge(dataTypeValueSchema(converted)); err != nil {
+			return nil, nil, err
+		}
+	}
+	return schema, items, nil
+}
+
+// dataTypeValueSchema returns the schema of the value converted by DataTypeConvert.
+func dataTypeValueSchema(value any) *Schema {
+	switch v := value.(type) {
+	case *ListType:
+		return &Schema{Type: DataTypeList, Item: v.ItemType}
+	case *MapType:
+		return &Schema{Type: DataTypeMap, Key: v.KeyType, Value: v.ValueType}
+	case *StructType:
+		return &Schema{Type: DataTypeStruct, Fields: v.Fields}
+	case Decimal:
+		scale := max(v.scale(), 0)
+		digits := max(v.Precision()-v.scale(), 0)
+		return &Schema{Type: DataTypeDecimal, Precision: min(max(digits+scale, 1), DecimalMaxPrecision), Scale: scale}
+	default:
+		return nil
+	}
+}
+
+// merge returns the schema describing values of both schemas. Null schemas are unknown
+// and take the other schema, struct fields are merged by name and decimals take the
+// widest integer part and scale.
+func (s *Schema) merge(other *Schema) (*Schema, error) {
+	switch {
+	case other == nil || other.Type == DataTypeNull:
+		return s, nil
+	case s == nil || s.Type == DataTypeNull:
+		return other, nil
+	case s.Type != other.Type:
+		return nil, ErrConversionNotSupported.Wrapf("mixed item data types %s and %s", s, other)
+	}
+
+	merged := *s
+	var err error
+	switch s.Type {
+	case DataTypeList:
+		merged.Item, err = s.Item.merge(other.Item)
+	case DataTypeMap:
+		if merged.Key, err = s.Key.merge(other.Key); err == nil {
+			merged.Value, err = s.Value.merge(other.Value)
+		}
+	case DataTypeStruct:
+		merged.Fields = slices.Clone(s.Fields)
+		for _, field := range other.Fields {
+			i := slices.IndexFunc(merged.Fields, func(f StructField) bool { return f.Name == field.Name })
+			if i < 0 {
+				merged.Fields = append(merged.Fields, field)
+				continue
+			}
+			if merged.Fields[i].Type, err = merged.Fields[i].Type.merge(field.Type); err != nil {
+				return nil, ErrConversionNotSupported.Wrapf("field=%s", field.Name).Wrap(err)
+			}
+		}
+	case DataTypeDecimal:
+		if s.Precision == 0 {
+			merged.Precision, merged.Scale = other.Precision, other.Scale
+		} else if other.Precision > 0 {
+			merged.Scale = max(s.Scale, other.Scale)
+			merged.Precision = min(max(s.Precision-s.Scale, other.Precision-other.Scale)+merged.Scale, DecimalMaxPrecision)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &merged, nil
+}
+
+// unixSeconds makes time.Time from seconds since unix epoch.
 func unixSeconds(seconds int64) time.Time {
 	return time.Unix(seconds, 0).UTC()
 }
 
-// unixMillisToTime makes time.Time from milliseconds since unix epoch.
+// unixMilliseconds makes time.Time from milliseconds since unix epoch.
 func unixMilliseconds(milliseconds int64) time.Time {
-	seconds := int64(milliseconds) / 1000
-	nanos := (milliseconds % seconds) * int64(time.Millisecond)
-	return time.Unix(seconds, nanos).UTC()
+	return time.UnixMilli(milliseconds).UTC()
 }
 
 // unixNanoseconds makes time.Time from nanoseconds since unix epoch.
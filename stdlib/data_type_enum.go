@@ -40,6 +40,8 @@ const (
 	DataTypeFloat32 DataType = "float32"
 	// DataTypeFloat64 is a DataType of type float64.
 	DataTypeFloat64 DataType = "float64"
+	// DataTypeDecimal is a DataType of type decimal.
+	DataTypeDecimal DataType = "decimal"
 	// DataTypeBytes is a DataType of type bytes.
 	DataTypeBytes DataType = "bytes"
 	// DataTypeUtf8 is a DataType of type utf8.
@@ -54,6 +56,10 @@ const (
 	DataTypeTimestampMs DataType = "timestamp_ms"
 	// DataTypeList is a DataType of type list.
 	DataTypeList DataType = "list"
+	// DataTypeMap is a DataType of type map.
+	DataTypeMap DataType = "map"
+	// DataTypeStruct is a DataType of type struct.
+	DataTypeStruct DataType = "struct"
 )
 
 var ErrInvalidDataType = fmt.Errorf("not a valid DataType, try [%s]", strings.Join(_DataTypeNames, ", "))
@@ -73,6 +79,7 @@ var _DataTypeNames = []string{
 	string(DataTypeUint128),
 	string(DataTypeFloat32),
 	string(DataTypeFloat64),
+	string(DataTypeDecimal),
 	string(DataTypeBytes),
 	string(DataTypeUtf8),
 	string(DataTypeDate),
@@ -80,6 +87,8 @@ var _DataTypeNames = []string{
 	string(DataTypeTimestampS),
 	string(DataTypeTimestampMs),
 	string(DataTypeList),
+	string(DataTypeMap),
+	string(DataTypeStruct),
 }
 
 // DataTypeNames returns a list of possible string values of DataType.
@@ -116,6 +125,7 @@ var _DataTypeValue = map[string]DataType{
 	"uint128":      DataTypeUint128,
 	"float32":      DataTypeFloat32,
 	"float64":      DataTypeFloat64,
+	"decimal":      DataTypeDecimal,
 	"bytes":        DataTypeBytes,
 	"utf8":         DataTypeUtf8,
 	"date":         DataTypeDate,
@@ -123,6 +133,8 @@ var _DataTypeValue = map[string]DataType{
 	"timestamp_s":  DataTypeTimestampS,
 	"timestamp_ms": DataTypeTimestampMs,
 	"list":         DataTypeList,
+	"map":          DataTypeMap,
+	"struct":       DataTypeStruct,
 }
 
 // ParseDataType attempts to convert a string to a DataType.
@@ -0,0 +1,219 @@
+package stdlib_test
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ahawker/stdlibx-go/stdlib"
+	"github.com/ahawker/stdlibx-go/stdtest"
+)
+
+type dataTypeColumn struct {
+	Name    string         `json:"name"`
+	Price   stdlib.Decimal `json:"price"`
+	Tags    []string       `json:"tags"`
+	Updated *time.Time     `json:"updated"`
+}
+
+func TestDataTypeConvert(t *testing.T) {
+	type Got struct {
+		dt    stdlib.DataType
+		value any
+	}
+	price := stdlib.Decimal{Unscaled: stdlib.Int128From64(1250), Scale: 2}
+	stdtest.Table[Got, any]{
+		"pass: zero to bool":                   {Got: Got{stdlib.DataTypeBool, 0}, Want: false},
+		"pass: one to bool":                    {Got: Got{stdlib.DataTypeBool, uint8(1)}, Want: true},
+		"fail: two to bool":                    {Got: Got{stdlib.DataTypeBool, 2}, WantErr: stdlib.ErrPrecisionLoss},
+		"pass: integral float to int8":         {Got: Got{stdlib.DataTypeInt8, 2.0}, Want: int8(2)},
+		"fail: fractional float to int8":       {Got: Got{stdlib.DataTypeInt8, 2.5}, WantErr: stdlib.ErrPrecisionLoss},
+		"fail: negative int to uint16":         {Got: Got{stdlib.DataTypeUint16, -1}, WantErr: stdlib.ErrPrecisionLoss},
+		"pass: float64":                        {Got: Got{stdlib.DataTypeFloat64, 1.5}, Want: 1.5},
+		"pass: int64 to float64":               {Got: Got{stdlib.DataTypeFloat64, int64(-3)}, Want: -3.0},
+		"fail: inexact int64 to float64":       {Got: Got{stdlib.DataTypeFloat64, int64(1<<53 + 1)}, WantErr: stdlib.ErrPrecisionLoss},
+		"pass: min int64 to int128":            {Got: Got{stdlib.DataTypeInt128, int64(math.MinInt64)}, Want: stdlib.Int128From64(math.MinInt64)},
+		"pass: min int128 string to int128":    {Got: Got{stdlib.DataTypeInt128, "-170141183460469231731687303715884105728"}, Want: stdlib.MinInt128},
+		"pass: leading zero string to int128":  {Got: Got{stdlib.DataTypeInt128, "010"}, Want: stdlib.Int128From64(10)},
+		"pass: leading zero string to uint128": {Got: Got{stdlib.DataTypeUint128, "010"}, Want: stdlib.Uint128From64(10)},
+		"pass: float to uint128":               {Got: Got{stdlib.DataTypeUint128, 1e20}, Want: stdlib.Uint128{Hi: 5, Lo: 7766279631452241920}},
+		"fail: negative int128 to uint128":     {Got: Got{stdlib.DataTypeUint128, stdlib.Int128From64(-1)}, WantErr: stdlib.ErrPrecisionLoss},
+		"fail: max int128 to int64":            {Got: Got{stdlib.DataTypeInt64, stdlib.MaxInt128}, WantErr: stdlib.ErrPrecisionLoss},
+		"pass: uint128 to uint64":              {Got: Got{stdlib.DataTypeUint64, stdlib.Uint128From64(7)}, Want: uint64(7)},
+		"pass: string to decimal":              {Got: Got{stdlib.DataTypeDecimal, "12.50"}, Want: price},
+		"pass: decimal to float64":             {Got: Got{stdlib.DataTypeFloat64, price}, Want: 12.5},
+		"fail: fractional decimal to int32":    {Got: Got{stdlib.DataTypeInt32, price}, WantErr: stdlib.ErrPrecisionLoss},
+		"pass: integral decimal to int32":      {Got: Got{stdlib.DataTypeInt32, stdlib.Decimal{Unscaled: stdlib.Int128From64(1200), Scale: 2}}, Want: int32(12)},
+		"pass: decimal to utf8":                {Got: Got{stdlib.DataTypeUtf8, price}, Want: "12.50"},
+		"fail: non-numeric string to int8":     {Got: Got{stdlib.DataTypeInt8, "abc"}, WantErr: stdlib.ErrConversionNotSupported},
+		"pass: negative scale decimal to utf8": {Got: Got{stdlib.DataTypeUtf8, stdlib.Decimal{Unscaled: stdlib.Int128From64(12), Scale: -2}}, Want: "1200"},
+	}.Run(t, func(t *stdtest.Test, tc stdtest.Testcase[Got, any]) {
+		got, err := stdlib.DataTypeConvert(tc.Got.dt, tc.Got.value)
+		if tc.WantErr != nil {
+			t.EqualError(err, tc.WantErr)
+			return
+		}
+		t.OK(err)
+		t.Equal(got, tc.Want)
+	})
+}
+
+func TestDataTypeMap(t *testing.T) {
+	stdtest.Table[any, *stdlib.MapType]{
+		"pass: string keys sorted": {
+			Got: map[string]any{"b": int8(2), "a": int8(1), "c": nil},
+			Want: &stdlib.MapType{
+				KeyType:   &stdlib.Schema{Type: stdlib.DataTypeUtf8},
+				ValueType: &stdlib.Schema{Type: stdlib.DataTypeInt8},
+				Entries: []stdlib.Pair[any, any]{
+					{First: "a", Second: int8(1)},
+					{First: "b", Second: int8(2)},
+					{First: "c", Second: nil},
+				},
+			},
+		},
+		"pass: int keys sorted by value": {
+			Got: map[int]string{9: "nine", 10: "ten", 2: "two"},
+			Want: &stdlib.MapType{
+				KeyType:   &stdlib.Schema{Type: stdlib.DataTypeInt64},
+				ValueType: &stdlib.Schema{Type: stdlib.DataTypeUtf8},
+				Entries: []stdlib.Pair[any, any]{
+					{First: int64(2), Second: "two"},
+					{First: int64(9), Second: "nine"},
+					{First: int64(10), Second: "ten"},
+				},
+			},
+		},
+		"pass: mixed int kinds sorted by value": {
+			Got: map[any]string{10: "a", 9: "b", int64(5): "c", 7: "d", int64(12): "e"},
+			Want: &stdlib.MapType{
+				KeyType:   &stdlib.Schema{Type: stdlib.DataTypeInt64},
+				ValueType: &stdlib.Schema{Type: stdlib.DataTypeUtf8},
+				Entries: []stdlib.Pair[any, any]{
+					{First: int64(5), Second: "c"},
+					{First: int64(7), Second: "d"},
+					{First: int64(9), Second: "b"},
+					{First: int64(10), Second: "a"},
+					{First: int64(12), Second: "e"},
+				},
+			},
+		},
+		"fail: not a map": {
+			Got:     []int{1},
+			WantErr: stdlib.ErrConversionNotSupported,
+		},
+	}.Run(t, func(t *stdtest.Test, tc stdtest.Testcase[any, *stdlib.MapType]) {
+		got, err := stdlib.Map(tc.Got)
+		if tc.WantErr != nil {
+			t.EqualError(err, tc.WantErr)
+			return
+		}
+		t.OK(err)
+		t.Equal(got, tc.Want)
+	})
+}
+
+func TestDataTypeMapNaNKey(t *testing.T) {
+	test := stdtest.NewTest(t)
+
+	// NaN keys can't be looked up, so entries must come from ranging the map.
+	m, err := stdlib.Map(map[float64]int{math.NaN(): 1, 1.5: 2})
+	test.OK(err)
+	test.Equal(len(m.Entries), 2)
+	test.True(math.IsNaN(m.Entries[0].First.(float64)), "want NaN key first got %v", m.Entries[0].First)
+	test.Equal(m.Entries[0].Second, int64(1))
+	test.Equal(m.Entries[1], stdlib.Pair[any, any]{First: 1.5, Second: int64(2)})
+}
+
+func TestDataTypeNested(t *testing.T) {
+	test := stdtest.NewTest(t)
+
+	list, err := stdlib.List([][]int{{1, 2}, {3}})
+	test.OK(err)
+	test.Equal(list.ItemType.String(), "list<int64>")
+	test.Equal(list.Items[1], &stdlib.ListType{ItemType: &stdlib.Schema{Type: stdlib.DataTypeInt64}, Items: []any{int64(3)}})
+
+	_, err = stdlib.List([]any{1, "two"})
+	test.True(errors.Is(err, stdlib.ErrConversionNotSupported), "want ErrConversionNotSupported got %v", err)
+	_, err = stdlib.List([]any{[]int{1}, []string{"two"}})
+	test.True(errors.Is(err, stdlib.ErrConversionNotSupported), "want ErrConversionNotSupported got %v", err)
+
+	price, err := stdlib.ParseDecimal("9.99")
+	test.OK(err)
+	s, err := stdlib.Struct(&dataTypeColumn{Name: "widget", Price: price, Tags: []string{"new"}})
+	test.OK(err)
+	test.Equal(s.Fields, []stdlib.StructField{
+		{Name: "name", Type: &stdlib.Schema{Type: stdlib.DataTypeUtf8}},
+		{Name: "price", Type: &stdlib.Schema{Type: stdlib.DataTypeDecimal, Precision: 3, Scale: 2}},
+		{Name: "tags", Type: &stdlib.Schema{Type: stdlib.DataTypeList, Item: &stdlib.Schema{Type: stdlib.DataTypeUtf8}}},
+		{Name: "updated", Type: &stdlib.Schema{Type: stdlib.DataTypeTimestamp}},
+	})
+	tags, ok := s.Get("tags")
+	test.True(ok, "want tags field")
+	test.Equal(tags, &stdlib.ListType{ItemType: &stdlib.Schema{Type: stdlib.DataTypeUtf8}, Items: []any{"new"}})
+	updated, _ := s.Get("updated")
+	test.Equal(updated, nil)
+
+	dt, err := stdlib.ReflectTypeToDataType(reflect.TypeFor[map[string]dataTypeColumn]())
+	test.OK(err)
+	test.Equal(dt, stdlib.DataTypeMap)
+}
+
+type dataTypeRow struct {
+	ID      int32                `json:"id"`
+	Grid    [][]int32            `json:"grid"`
+	Labels  dataTypeLabels       `json:"labels"`
+	Amounts []stdlib.Decimal     `json:"amounts"`
+	Extra   map[string][]float64 `json:"extra"`
+	Any     []any                `json:"any"`
+}
+
+type dataTypeLabels struct {
+	A []string `json:"a"`
+}
+
+type dataTypeTree struct {
+	Children []dataTypeTree `json:"children"`
+}
+
+func TestDataTypeSchema(t *testing.T) {
+	test := stdtest.NewTest(t)
+
+	schema, err := stdlib.ReflectTypeToSchema(reflect.TypeFor[[]dataTypeRow]())
+	test.OK(err)
+	test.Equal(schema.String(), "list<struct<id: int32, grid: list<list<int32>>, labels: struct<a: list<utf8>>, "+
+		"amounts: list<decimal>, extra: map<utf8, list<float64>>, any: list<null>>>")
+
+	_, err = stdlib.ReflectTypeToSchema(reflect.TypeFor[dataTypeTree]())
+	test.True(errors.Is(err, stdlib.ErrConversionNotSupported), "want ErrConversionNotSupported got %v", err)
+
+	// Values refine the schema derived from the type with decimal precision and scale
+	// and the item types of interface lists.
+	amount, err := stdlib.ParseDecimal("12.5")
+	test.OK(err)
+	fee, err := stdlib.ParseDecimal("0.05")
+	test.OK(err)
+	rows := []dataTypeRow{
+		{ID: 1, Grid: [][]int32{{1, 2}, {3}}, Labels: dataTypeLabels{A: []string{"x"}}, Amounts: []stdlib.Decimal{amount}},
+		{ID: 2, Amounts: []stdlib.Decimal{fee}, Any: []any{[]int32{4}}},
+	}
+	list, err := stdlib.List(rows)
+	test.OK(err)
+	test.Equal(list.ItemType.String(), "struct<id: int32, grid: list<list<int32>>, labels: struct<a: list<utf8>>, "+
+		"amounts: list<decimal(4,2)>, extra: map<utf8, list<float64>>, any: list<list<int32>>>")
+
+	// Converting the converted values again keeps the schema and values.
+	again, err := stdlib.DataTypeConvert(stdlib.DataTypeList, list)
+	test.OK(err)
+	test.Equal(again, list)
+	row := list.Items[0].(*stdlib.StructType)
+	grid, _ := row.Get("grid")
+	test.Equal(grid.(*stdlib.ListType).ItemType.String(), "list<int32>")
+	test.Equal(grid.(*stdlib.ListType).Items[1], &stdlib.ListType{ItemType: &stdlib.Schema{Type: stdlib.DataTypeInt32}, Items: []any{int32(3)}})
+	labels, _ := row.Get("labels")
+	test.Equal(labels.(*stdlib.StructType).Fields, []stdlib.StructField{
+		{Name: "a", Type: &stdlib.Schema{Type: stdlib.DataTypeList, Item: &stdlib.Schema{Type: stdlib.DataTypeUtf8}}},
+	})
+}
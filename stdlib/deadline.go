@@ -0,0 +1,107 @@
+package stdlib
+
+import (
+	"context"
+	"time"
+)
+
+// DeadlineHeader is the header/metadata key deadlines are carried under.
+const DeadlineHeader = "X-Deadline-Remaining"
+
+var _ DeadlineCarrier = DeadlineMapCarrier(nil)
+
+// ErrDeadlineInvalid is returned when a carried deadline cannot be parsed.
+var ErrDeadlineInvalid = Error{
+	Code:      "deadline_invalid",
+	Message:   "carried deadline is not a valid duration",
+	Namespace: ErrorNamespaceDefault,
+}
+
+// DeadlineCarrier describes header/metadata containers deadlines can be
+// serialised into and out of, e.g. 'http.Header'.
+type DeadlineCarrier interface {
+	// Get returns the value for the key or an empty string.
+	Get(key string) string
+	// Set sets the value for the key.
+	Set(key, value string)
+}
+
+// DeadlineMapCarrier is a DeadlineCarrier backed by a map, e.g. for RPC metadata.
+type DeadlineMapCarrier map[string]string
+
+// Get returns the value for the key or an empty string.
+//
+// Interface: DeadlineCarrier.
+func (c DeadlineMapCarrier) Get(key string) string {
+	return c[key]
+}
+
+// Set sets the value for the key.
+//
+// Interface: DeadlineCarrier.
+func (c DeadlineMapCarrier) Set(key, value string) {
+	c[key] = value
+}
+
+// Remaining returns the time left until the context deadline and reports
+// whether the context has one.
+func Remaining(ctx context.Context) (time.Duration, bool) {
+	return remaining(ctx, DefaultClock)
+}
+
+// WithBudget returns a copy of ctx with a deadline that is the given fraction
+// of the remaining deadline, reserving the rest for the caller. The fraction is
+// clamped to [0, 1]. If ctx has no deadline, the copy only adds cancellation.
+func WithBudget(ctx context.Context, fraction float64) (context.Context, context.CancelFunc) {
+	d, ok := budget(ctx, DefaultClock, fraction)
+	if !ok {
+		return context.WithCancel(ctx)
+	}
+	return DefaultClock.WithTimeout(ctx, d)
+}
+
+// DeadlineInject writes the remaining deadline of the context into the carrier
+// and reports whether the context has one.
+func DeadlineInject(ctx context.Context, carrier DeadlineCarrier) bool {
+	d, ok := Remaining(ctx)
+	if !ok {
+		return false
+	}
+	carrier.Set(DeadlineHeader, max(d, 0).String())
+	return true
+}
+
+// DeadlineExtract returns a copy of ctx with the deadline read from the
+// carrier. If the carrier has no deadline, the copy only adds cancellation.
+func DeadlineExtract(ctx context.Context, carrier DeadlineCarrier) (context.Context, context.CancelFunc, error) {
+	value := carrier.Get(DeadlineHeader)
+	if value == "" {
+		ctx, cancel := context.WithCancel(ctx)
+		return ctx, cancel, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return nil, nil, ErrDeadlineInvalid.Wrap(err)
+	}
+	ctx, cancel := DefaultClock.WithTimeout(ctx, d)
+	return ctx, cancel, nil
+}
+
+// remaining returns the time left until the context deadline using the clock.
+func remaining(ctx context.Context, clock Clock) (time.Duration, bool) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return 0, false
+	}
+	return deadline.Sub(clock.Now()), true
+}
+
+// budget returns the fraction of the remaining deadline using the clock.
+func budget(ctx context.Context, clock Clock, fraction float64) (time.Duration, bool) {
+	d, ok := remaining(ctx, clock)
+	if !ok {
+		return 0, false
+	}
+	fraction = min(max(fraction, 0), 1)
+	return time.Duration(float64(max(d, 0)) * fraction), true
+}
@@ -0,0 +1,55 @@
+package stdlib_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ahawker/stdlibx-go/stdlib"
+	"github.com/ahawker/stdlibx-go/stdtest"
+)
+
+func TestDeadlineBudget(t *testing.T) {
+	test := stdtest.NewTest(t)
+
+	_, ok := stdlib.Remaining(context.Background())
+	test.False(ok, "want no deadline")
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	budget, cancel := stdlib.WithBudget(ctx, 0.5)
+	defer cancel()
+	remaining, ok := stdlib.Remaining(budget)
+	test.True(ok, "want deadline")
+	test.True(remaining > 25*time.Second && remaining <= 30*time.Second, "want ~30s got %s", remaining)
+
+	// Deadlines round trip through headers and metadata maps.
+	header := http.Header{}
+	test.True(stdlib.DeadlineInject(budget, header), "want deadline injected")
+	extracted, cancel, err := stdlib.DeadlineExtract(context.Background(), header)
+	test.OK(err)
+	defer cancel()
+	remaining, ok = stdlib.Remaining(extracted)
+	test.True(ok, "want deadline")
+	test.True(remaining > 25*time.Second && remaining <= 30*time.Second, "want ~30s got %s", remaining)
+
+	_, _, err = stdlib.DeadlineExtract(context.Background(), stdlib.DeadlineMapCarrier{stdlib.DeadlineHeader: "soon"})
+	test.True(errors.Is(err, stdlib.ErrDeadlineInvalid), "want ErrDeadlineInvalid got %v", err)
+
+	// Task timeout is derived from the remaining budget.
+	clock := stdlib.NewFakeClock(epoch)
+	ctx, cancel = clock.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	err = stdlib.Task(ctx, func(ctx context.Context) error {
+		remaining, _ := ctx.Deadline()
+		test.Equal(remaining, epoch.Add(15*time.Second))
+		return nil
+	}, stdlib.WithTaskBudget(0.25), stdlib.WithTaskClock(clock))
+	test.OK(err)
+
+	err = stdlib.Task(ctx, func(context.Context) error { return nil }, stdlib.WithTaskBudget(2))
+	test.EqualError(err, stdlib.ErrTaskConfig)
+}
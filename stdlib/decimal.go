@@ -0,0 +1,205 @@
+package stdlib
+
+import (
+	"encoding"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+var (
+	_ fmt.Stringer             = Decimal{}
+	_ encoding.TextMarshaler   = Decimal{}
+	_ encoding.TextUnmarshaler = (*Decimal)(nil)
+)
+
+// DecimalMaxPrecision is the max number of significant digits of a Decimal, the most
+// that always fit its Int128, matching Arrow and Parquet decimal128 columns.
+const DecimalMaxPrecision = 38
+
+// Decimal is a fixed-precision decimal number of an unscaled Int128 and a scale,
+// e.g. 123.45 is 12345 at scale 2.
+//
+// Values have at most DecimalMaxPrecision digits and scales from zero to DecimalMaxPrecision.
+// Scales of struct literals beyond ±DecimalMaxPrecision are bounded to it.
+type Decimal struct {
+	// Unscaled is the value without the decimal point.
+	Unscaled Int128
+	// Scale is the number of digits after the decimal point.
+	Scale int32
+}
+
+// NewDecimal returns the Decimal of the unscaled value and scale and ErrPrecisionLoss
+// if either is out of range.
+func NewDecimal(unscaled Int128, scale int) (Decimal, error) {
+	return decimalFromBig(unscaled.Big(), scale)
+}
+
+// ParseDecimal parses the string as a decimal number with an optional exponent,
+// e.g. "-123.45" or "1.5e3". The scale is the number of digits after the point.
+func ParseDecimal(s string) (Decimal, error) {
+	failed := func(err error) (Decimal, error) {
+		return Decimal{}, ErrTypeConversionFailed.Wrapf("value=%q desired_type=decimal", s).Wrap(err)
+	}
+
+	str, exp := strings.TrimSpace(s), 0
+	if i := strings.IndexAny(str, "eE"); i >= 0 {
+		e, err := strconv.Atoi(str[i+1:])
+		if err != nil {
+			return failed(err)
+		}
+		str, exp = str[:i], e
+	}
+	whole, frac, _ := strings.Cut(str, ".")
+	if strings.ContainsAny(frac, "+-") {
+		return failed(nil)
+	}
+	b, ok := new(big.Int).SetString(whole+frac, 10)
+	if !ok {
+		return failed(nil)
+	}
+
+	// Bound the exponent before scaling, so e.g. "1e3000000" fails fast.
+	switch {
+	case exp > len(frac)+DecimalMaxPrecision && b.Sign() == 0:
+		exp = len(frac)
+	case exp > len(frac)+DecimalMaxPrecision || exp < len(frac)-DecimalMaxPrecision:
+		return Decimal{}, ErrPrecisionLoss.Wrapf("from=string to=decimal value=%q", s)
+	}
+	scale := len(frac) - exp
+	if scale < 0 {
+		b.Mul(b, decimalPow10(-scale))
+		scale = 0
+	}
+	return decimalFromBig(b, scale)
+}
+
+// DecimalFromFloat64 returns the shortest Decimal that represents the float64 and
+// ErrPrecisionLoss if it does not fit.
+func DecimalFromFloat64(f float64) (Decimal, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return Decimal{}, ErrPrecisionLoss.Wrapf("from=float64 to=decimal value=%v", f)
+	}
+	return ParseDecimal(strconv.FormatFloat(f, 'g', -1, 64))
+}
+
+// IsZero returns true if the value is zero.
+func (d Decimal) IsZero() bool {
+	return d.Unscaled.IsZero()
+}
+
+// Precision returns the number of significant digits of the unscaled value.
+func (d Decimal) Precision() int {
+	return len(new(big.Int).Abs(d.Unscaled.Big()).String())
+}
+
+// Cmp returns -1, 0 or +1 if the value is less than, equal to or greater than v.
+func (d Decimal) Cmp(v Decimal) int {
+	return d.Rat().Cmp(v.Rat())
+}
+
+// Rescale returns the value at the scale and ErrPrecisionLoss if non-zero digits
+// would be dropped, the precision exceeded or the scale is out of range.
+func (d Decimal) Rescale(scale int) (Decimal, error) {
+	if scale < 0 || scale > DecimalMaxPrecision {
+		return Decimal{}, ErrPrecisionLoss.Wrapf("from=decimal to=decimal(%d) value=%s", scale, d)
+	}
+	b, diff := d.Unscaled.Big(), scale-d.scale()
+	if diff >= 0 {
+		return decimalFromBig(b.Mul(b, decimalPow10(diff)), scale)
+	}
+	q, r := b.QuoRem(b, decimalPow10(-diff), new(big.Int))
+	if r.Sign() != 0 {
+		return Decimal{}, ErrPrecisionLoss.Wrapf("from=decimal to=decimal(%d) value=%s", scale, d)
+	}
+	return decimalFromBig(q, scale)
+}
+
+// Rat returns the value as a big.Rat.
+func (d Decimal) Rat() *big.Rat {
+	b, scale := d.Unscaled.Big(), d.scale()
+	if scale < 0 {
+		return new(big.Rat).SetInt(b.Mul(b, decimalPow10(-scale)))
+	}
+	return new(big.Rat).SetFrac(b, decimalPow10(scale))
+}
+
+// Float64 returns the nearest float64 and reports whether it is exact.
+func (d Decimal) Float64() (float64, bool) {
+	return d.Rat().Float64()
+}
+
+// String returns the decimal representation with exactly Scale digits after the point.
+// A negative Scale, e.g. of a struct literal, appends zeros to the unscaled value.
+//
+// Interface: fmt.Stringer.
+func (d Decimal) String() string {
+	b := d.Unscaled.Big()
+	digits := new(big.Int).Abs(b).String()
+	scale := d.scale()
+	if scale < 0 {
+		if b.Sign() != 0 {
+			digits += strings.Repeat("0", -scale)
+		}
+		scale = 0
+	} else if len(digits) <= scale {
+		digits = strings.Repeat("0", scale-len(digits)+1) + digits
+	}
+
+	var sb strings.Builder
+	if b.Sign() < 0 {
+		sb.WriteByte('-')
+	}
+	sb.WriteString(digits[:len(digits)-scale])
+	if scale > 0 {
+		sb.WriteByte('.')
+		sb.WriteString(digits[len(digits)-scale:])
+	}
+	return sb.String()
+}
+
+// scale returns the Scale bounded to -DecimalMaxPrecision..DecimalMaxPrecision, so
+// struct literals with extreme scales can't make Rat, String or Rescale compute huge
+// powers of ten. Valid values, e.g. from ParseDecimal, are always in range.
+func (d Decimal) scale() int {
+	return min(max(int(d.Scale), -DecimalMaxPrecision), DecimalMaxPrecision)
+}
+
+// MarshalText encodes the value as a decimal string.
+//
+// Interface: encoding.TextMarshaler.
+func (d Decimal) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText decodes the value with ParseDecimal.
+//
+// Interface: encoding.TextUnmarshaler.
+func (d *Decimal) UnmarshalText(text []byte) error {
+	v, err := ParseDecimal(string(text))
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+// decimalFromBig returns the Decimal of the unscaled value and scale and ErrPrecisionLoss
+// if either is out of range.
+func decimalFromBig(unscaled *big.Int, scale int) (Decimal, error) {
+	if scale < 0 || scale > DecimalMaxPrecision || len(new(big.Int).Abs(unscaled).String()) > DecimalMaxPrecision {
+		return Decimal{}, ErrPrecisionLoss.Wrapf("from=*big.Int to=decimal value=%s scale=%d", unscaled, scale)
+	}
+	i, err := Int128FromBig(unscaled)
+	if err != nil {
+		return Decimal{}, err
+	}
+	return Decimal{Unscaled: i, Scale: int32(scale)}, nil
+}
+
+// decimalPow10 returns 10^n.
+func decimalPow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
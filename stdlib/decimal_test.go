@@ -0,0 +1,91 @@
+package stdlib_test
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ahawker/stdlibx-go/stdlib"
+	"github.com/ahawker/stdlibx-go/stdtest"
+)
+
+func TestParseDecimal(t *testing.T) {
+	stdtest.Table[string, string]{
+		"pass: negative with scale":     {Got: "-123.45", Want: "-123.45"},
+		"pass: leading zero fraction":   {Got: "0.05", Want: "0.05"},
+		"pass: positive exponent":       {Got: "1.5e3", Want: "1500"},
+		"pass: negative exponent":       {Got: "15e-3", Want: "0.015"},
+		"pass: zero with huge exponent": {Got: "0e3000000", Want: "0"},
+		"fail: more than max precision": {Got: "123456789012345678901234567890123456789", WantErr: stdlib.ErrPrecisionLoss},
+		"fail: scale beyond max":        {Got: "1e-39", WantErr: stdlib.ErrPrecisionLoss},
+		"fail: huge positive exponent":  {Got: "1e3000000", WantErr: stdlib.ErrPrecisionLoss},
+		"fail: huge negative exponent":  {Got: "1e-3000000", WantErr: stdlib.ErrPrecisionLoss},
+		"fail: two decimal points":      {Got: "1.2.3", WantErr: stdlib.ErrTypeConversionFailed},
+		"fail: sign in fraction":        {Got: "1.-2", WantErr: stdlib.ErrTypeConversionFailed},
+		"fail: exponent out of int64":   {Got: "1e99999999999999999999", WantErr: stdlib.ErrTypeConversionFailed},
+	}.Run(t, func(t *stdtest.Test, tc stdtest.Testcase[string, string]) {
+		d, err := stdlib.ParseDecimal(tc.Got)
+		if tc.WantErr != nil {
+			t.EqualError(err, tc.WantErr)
+			return
+		}
+		t.OK(err)
+		t.Equal(d.String(), tc.Want)
+	})
+}
+
+func TestDecimal(t *testing.T) {
+	test := stdtest.NewTest(t)
+
+	parse := func(s string) stdlib.Decimal {
+		t.Helper()
+		d, err := stdlib.ParseDecimal(s)
+		test.OK(err)
+		return d
+	}
+
+	d := parse("-123.45")
+	test.Equal(d, stdlib.Decimal{Unscaled: stdlib.Int128From64(-12345), Scale: 2})
+	test.Equal(d.Precision(), 5)
+	test.Equal(parse("10.50").Cmp(parse("10.5")), 0)
+
+	up, err := d.Rescale(4)
+	test.OK(err)
+	test.Equal(up.String(), "-123.4500")
+	_, err = up.Rescale(1)
+	test.True(errors.Is(err, stdlib.ErrPrecisionLoss), "want ErrPrecisionLoss got %v", err)
+	down, err := parse("2.50").Rescale(1)
+	test.OK(err)
+	test.Equal(down.String(), "2.5")
+
+	f, exact := parse("0.25").Float64()
+	test.Equal(f, 0.25)
+	test.True(exact, "want exact float")
+	fd, err := stdlib.DecimalFromFloat64(0.1)
+	test.OK(err)
+	test.Equal(fd.String(), "0.1")
+
+	// A negative scale, e.g. of a struct literal, multiplies the unscaled value.
+	neg := stdlib.Decimal{Unscaled: stdlib.Int128From64(-12), Scale: -2}
+	test.Equal(neg.String(), "-1200")
+	test.Equal(neg.Rat().Cmp(big.NewRat(-1200, 1)), 0)
+	test.Equal(neg.Cmp(parse("-1200.0")), 0)
+	test.Equal(stdlib.Decimal{Scale: -2}.String(), "0")
+
+	// Target scales out of range fail before computing any power of ten.
+	_, err = d.Rescale(1 << 28)
+	test.True(errors.Is(err, stdlib.ErrPrecisionLoss), "want ErrPrecisionLoss got %v", err)
+	_, err = d.Rescale(-1)
+	test.True(errors.Is(err, stdlib.ErrPrecisionLoss), "want ErrPrecisionLoss got %v", err)
+
+	// Extreme scales of struct literals are bounded to the max precision.
+	huge := stdlib.Decimal{Unscaled: stdlib.Int128From64(1), Scale: math.MinInt32}
+	test.Equal(huge.String(), "1"+strings.Repeat("0", stdlib.DecimalMaxPrecision))
+	test.Equal(huge.Rat().Cmp(new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(stdlib.DecimalMaxPrecision), nil))), 0)
+	tiny := stdlib.Decimal{Unscaled: stdlib.Int128From64(1), Scale: math.MaxInt32}
+	test.Equal(tiny.String(), "0."+strings.Repeat("0", stdlib.DecimalMaxPrecision-1)+"1")
+	_, err = tiny.Rescale(0)
+	test.True(errors.Is(err, stdlib.ErrPrecisionLoss), "want ErrPrecisionLoss got %v", err)
+}
@@ -680,3 +680,13 @@ func ErrorJoin(err error, errs ...error) *ErrorGroup {
 		return eg
 	}
 }
+
+// errorWithTag returns an *ErrorGroup containing err where each error
+// has the given tags added.
+func errorWithTag(err error, tags ...string) *ErrorGroup {
+	eg := NewErrorGroup(err)
+	for i := range eg.Errors {
+		eg.Errors[i] = eg.Errors[i].WithTag(tags...)
+	}
+	return eg
+}
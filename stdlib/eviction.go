@@ -0,0 +1,262 @@
+//go:generate go-enum --marshal --names
+package stdlib
+
+import (
+	"container/list"
+	"io"
+)
+
+// EvictionPolicy is the strategy used to choose which entry to evict from
+// a bounded collection.
+//
+// lru: Evict the least recently used entry.
+// lfu: Evict the least frequently used entry, ties broken by least recently used.
+// arc: Adaptive replacement cache; balances recency and frequency based on workload.
+//
+// ENUM(lru, lfu, arc).
+type EvictionPolicy string
+
+// EvictCloser is an eviction callback that closes values implementing io.Closer.
+func EvictCloser[K comparable, V any](_ K, v V) {
+	if c, ok := any(v).(io.Closer); ok {
+		_ = c.Close()
+	}
+}
+
+// evictor tracks key usage and chooses keys to evict.
+type evictor[K comparable] interface {
+	// add records a newly inserted key.
+	add(k K)
+	// access records a hit for an existing key.
+	access(k K)
+	// remove forgets the key.
+	remove(k K)
+	// victim chooses and forgets the key to evict to make room for incoming.
+	victim(incoming K) (K, bool)
+}
+
+// newEvictor returns the evictor for the policy and capacity.
+func newEvictor[K comparable](policy EvictionPolicy, capacity int) evictor[K] {
+	switch policy {
+	case EvictionPolicyLfu:
+		return newLFU[K]()
+	case EvictionPolicyArc:
+		return newARC[K](capacity)
+	default:
+		return newLRU[K]()
+	}
+}
+
+// lru is a least recently used evictor. The front of the list is most recent.
+type lru[K comparable] struct {
+	order *list.List
+	index map[K]*list.Element
+}
+
+func newLRU[K comparable]() *lru[K] {
+	return &lru[K]{order: list.New(), index: make(map[K]*list.Element)}
+}
+
+func (e *lru[K]) add(k K) {
+	e.index[k] = e.order.PushFront(k)
+}
+
+func (e *lru[K]) access(k K) {
+	if el, ok := e.index[k]; ok {
+		e.order.MoveToFront(el)
+	}
+}
+
+func (e *lru[K]) remove(k K) {
+	if el, ok := e.index[k]; ok {
+		e.order.Remove(el)
+		delete(e.index, k)
+	}
+}
+
+func (e *lru[K]) victim(K) (K, bool) {
+	el := e.order.Back()
+	if el == nil {
+		return *new(K), false
+	}
+	k := el.Value.(K)
+	e.remove(k)
+	return k, true
+}
+
+// lfu is a least frequently used evictor with O(1) operations. Keys are kept in
+// per-frequency buckets ordered by recency.
+type lfu[K comparable] struct {
+	freq    map[K]int
+	index   map[K]*list.Element
+	buckets map[int]*list.List
+	min     int
+}
+
+func newLFU[K comparable]() *lfu[K] {
+	return &lfu[K]{
+		freq:    make(map[K]int),
+		index:   make(map[K]*list.Element),
+		buckets: make(map[int]*list.List),
+	}
+}
+
+func (e *lfu[K]) push(k K, freq int) {
+	b, ok := e.buckets[freq]
+	if !ok {
+		b = list.New()
+		e.buckets[freq] = b
+	}
+	e.freq[k] = freq
+	e.index[k] = b.PushFront(k)
+}
+
+func (e *lfu[K]) unlink(k K) int {
+	freq := e.freq[k]
+	b := e.buckets[freq]
+	b.Remove(e.index[k])
+	if b.Len() == 0 {
+		delete(e.buckets, freq)
+	}
+	delete(e.freq, k)
+	delete(e.index, k)
+	return freq
+}
+
+func (e *lfu[K]) add(k K) {
+	e.push(k, 1)
+	e.min = 1
+}
+
+func (e *lfu[K]) access(k K) {
+	if _, ok := e.freq[k]; !ok {
+		return
+	}
+	freq := e.unlink(k)
+	if freq == e.min {
+		if _, ok := e.buckets[freq]; !ok {
+			e.min = freq + 1
+		}
+	}
+	e.push(k, freq+1)
+}
+
+func (e *lfu[K]) remove(k K) {
+	if _, ok := e.freq[k]; !ok {
+		return
+	}
+	freq := e.unlink(k)
+	if freq == e.min {
+		e.resetMin()
+	}
+}
+
+func (e *lfu[K]) resetMin() {
+	e.min = 0
+	for freq := range e.buckets {
+		if e.min == 0 || freq < e.min {
+			e.min = freq
+		}
+	}
+}
+
+func (e *lfu[K]) victim(K) (K, bool) {
+	b, ok := e.buckets[e.min]
+	if !ok {
+		e.resetMin()
+		if b, ok = e.buckets[e.min]; !ok {
+			return *new(K), false
+		}
+	}
+	k := b.Back().Value.(K)
+	e.remove(k)
+	return k, true
+}
+
+// arc is an adaptive replacement cache evictor.
+//
+// t1/t2 hold resident keys seen once/more than once, while b1/b2 are "ghost"
+// lists of recently evicted keys used to adapt the target size p of t1.
+//
+// Ref: https://www.usenix.org/legacy/events/fast03/tech/full_papers/megiddo/megiddo.pdf
+type arc[K comparable] struct {
+	capacity       int
+	p              int
+	t1, t2, b1, b2 *list.List
+	index          map[K]*list.Element
+	lists          map[K]*list.List
+}
+
+func newARC[K comparable](capacity int) *arc[K] {
+	return &arc[K]{
+		capacity: max(capacity, 1),
+		t1:       list.New(),
+		t2:       list.New(),
+		b1:       list.New(),
+		b2:       list.New(),
+		index:    make(map[K]*list.Element),
+		lists:    make(map[K]*list.List),
+	}
+}
+
+func (e *arc[K]) move(k K, to *list.List) {
+	e.unlink(k)
+	e.index[k] = to.PushFront(k)
+	e.lists[k] = to
+}
+
+func (e *arc[K]) unlink(k K) {
+	if l, ok := e.lists[k]; ok {
+		l.Remove(e.index[k])
+		delete(e.index, k)
+		delete(e.lists, k)
+	}
+}
+
+func (e *arc[K]) add(k K) {
+	switch e.lists[k] {
+	case e.b1:
+		e.p = min(e.capacity, e.p+max(e.b2.Len()/e.b1.Len(), 1))
+		e.move(k, e.t2)
+	case e.b2:
+		e.p = max(0, e.p-max(e.b1.Len()/e.b2.Len(), 1))
+		e.move(k, e.t2)
+	default:
+		e.move(k, e.t1)
+	}
+
+	// Bound the ghost lists so the directory never tracks more than 2c keys.
+	for e.t1.Len()+e.b1.Len() > e.capacity && e.b1.Len() > 0 {
+		e.unlink(e.b1.Back().Value.(K))
+	}
+	for e.t1.Len()+e.t2.Len()+e.b1.Len()+e.b2.Len() > 2*e.capacity && e.b2.Len() > 0 {
+		e.unlink(e.b2.Back().Value.(K))
+	}
+}
+
+func (e *arc[K]) access(k K) {
+	if l := e.lists[k]; l == e.t1 || l == e.t2 {
+		e.move(k, e.t2)
+	}
+}
+
+func (e *arc[K]) remove(k K) {
+	e.unlink(k)
+}
+
+func (e *arc[K]) victim(incoming K) (K, bool) {
+	var from, ghost *list.List
+	switch {
+	case e.t1.Len() > 0 && (e.t1.Len() > e.p || (e.lists[incoming] == e.b2 && e.t1.Len() == e.p)):
+		from, ghost = e.t1, e.b1
+	case e.t2.Len() > 0:
+		from, ghost = e.t2, e.b2
+	case e.t1.Len() > 0:
+		from, ghost = e.t1, e.b1
+	default:
+		return *new(K), false
+	}
+	k := from.Back().Value.(K)
+	e.move(k, ghost)
+	return k, true
+}
@@ -0,0 +1,77 @@
+// Code generated by go-enum DO NOT EDIT.
+// Version: 0.6.0
+// Revision: 919e61c0174b91303753ee3898569a01abb32c97
+// Build Date: 2023-12-18T15:54:43Z
+// Built By: goreleaser
+
+package stdlib
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// EvictionPolicyLru is a EvictionPolicy of type lru.
+	EvictionPolicyLru EvictionPolicy = "lru"
+	// EvictionPolicyLfu is a EvictionPolicy of type lfu.
+	EvictionPolicyLfu EvictionPolicy = "lfu"
+	// EvictionPolicyArc is a EvictionPolicy of type arc.
+	EvictionPolicyArc EvictionPolicy = "arc"
+)
+
+var ErrInvalidEvictionPolicy = fmt.Errorf("not a valid EvictionPolicy, try [%s]", strings.Join(_EvictionPolicyNames, ", "))
+
+var _EvictionPolicyNames = []string{
+	string(EvictionPolicyLru),
+	string(EvictionPolicyLfu),
+	string(EvictionPolicyArc),
+}
+
+// EvictionPolicyNames returns a list of possible string values of EvictionPolicy.
+func EvictionPolicyNames() []string {
+	tmp := make([]string, len(_EvictionPolicyNames))
+	copy(tmp, _EvictionPolicyNames)
+	return tmp
+}
+
+// String implements the Stringer interface.
+func (x EvictionPolicy) String() string {
+	return string(x)
+}
+
+// IsValid provides a quick way to determine if the typed value is
+// part of the allowed enumerated values
+func (x EvictionPolicy) IsValid() bool {
+	_, err := ParseEvictionPolicy(string(x))
+	return err == nil
+}
+
+var _EvictionPolicyValue = map[string]EvictionPolicy{
+	"lru": EvictionPolicyLru,
+	"lfu": EvictionPolicyLfu,
+	"arc": EvictionPolicyArc,
+}
+
+// ParseEvictionPolicy attempts to convert a string to a EvictionPolicy.
+func ParseEvictionPolicy(name string) (EvictionPolicy, error) {
+	if x, ok := _EvictionPolicyValue[name]; ok {
+		return x, nil
+	}
+	return EvictionPolicy(""), fmt.Errorf("%s is %w", name, ErrInvalidEvictionPolicy)
+}
+
+// MarshalText implements the text marshaller method.
+func (x EvictionPolicy) MarshalText() ([]byte, error) {
+	return []byte(string(x)), nil
+}
+
+// UnmarshalText implements the text unmarshaller method.
+func (x *EvictionPolicy) UnmarshalText(text []byte) error {
+	tmp, err := ParseEvictionPolicy(string(text))
+	if err != nil {
+		return err
+	}
+	*x = tmp
+	return nil
+}
@@ -0,0 +1,204 @@
+package stdlib
+
+import (
+	"context"
+	"fmt"
+)
+
+// ErrFuturePending is returned when reading the result of a future that has not completed.
+var ErrFuturePending = Error{
+	Code:      "future_pending",
+	Message:   "future has not completed",
+	Namespace: ErrorNamespaceDefault,
+}
+
+// ErrFutureEmpty is returned when combining an empty set of futures that requires at least one.
+var ErrFutureEmpty = Error{
+	Code:      "future_empty",
+	Message:   "no futures given to wait on",
+	Namespace: ErrorNamespaceDefault,
+}
+
+// Go runs the given function asynchronously and returns a *Future for its result.
+//
+// The function is executed with 'Task', so options such as timeouts and cancel
+// functions apply. The result is computed once and cached like 'Memoize'.
+func Go[T any](ctx context.Context, fn func(ctx context.Context) (T, error), options ...Option[*TaskConfig]) *Future[T] {
+	f := &Future[T]{done: make(chan struct{})}
+	f.memo.Fn = func() (T, error) {
+		var value T
+		err := Task(ctx, func(ctx context.Context) error {
+			var err error
+			value, err = fn(ctx)
+			return err
+		}, options...)
+		// The worker may have been abandoned, so only read its value on success.
+		if err != nil {
+			return *new(T), err
+		}
+		return value, nil
+	}
+
+	go func() {
+		defer close(f.done)
+		_, _ = f.memo.Get()
+	}()
+	return f
+}
+
+// Future is the result of an asynchronous computation.
+type Future[T any] struct {
+	// memo caches the result of the computation.
+	memo Memoize[T]
+	// done is closed once the computation has completed.
+	done chan struct{}
+}
+
+// Done returns a channel that is closed once the future has completed.
+func (f *Future[T]) Done() <-chan struct{} {
+	return f.done
+}
+
+// Await blocks until the future completes or the context is done.
+func (f *Future[T]) Await(ctx context.Context) (T, error) {
+	select {
+	case <-f.done:
+		return f.memo.Get()
+	case <-ctx.Done():
+		return *new(T), context.Cause(ctx)
+	}
+}
+
+// Result returns the result of the future without blocking.
+//
+// If the future has not completed, ErrFuturePending is returned.
+func (f *Future[T]) Result() (T, error) {
+	select {
+	case <-f.done:
+		return f.memo.Get()
+	default:
+		return *new(T), ErrFuturePending
+	}
+}
+
+// FutureAll returns a *Future that completes with the values of all futures, in order.
+//
+// If any fail, the errors are aggregated into an ErrorGroup where each is tagged
+// with the index of the failed future.
+func FutureAll[T any](ctx context.Context, futures ...*Future[T]) *Future[[]T] {
+	return Go(ctx, func(ctx context.Context) ([]T, error) {
+		values := make([]T, len(futures))
+		eg := NewErrorGroup()
+		for i, f := range futures {
+			v, err := f.Await(ctx)
+			if err != nil {
+				eg.Append(errorWithTag(err, futureTag(i)))
+				continue
+			}
+			values[i] = v
+		}
+		if err := eg.ErrorOrNil(); err != nil {
+			return nil, err
+		}
+		return values, nil
+	})
+}
+
+// FutureAny returns a *Future that completes with the value of the first future
+// to succeed.
+//
+// If all fail, the errors are aggregated into an ErrorGroup where each is tagged
+// with the index of the failed future.
+func FutureAny[T any](ctx context.Context, futures ...*Future[T]) *Future[T] {
+	return Go(ctx, func(ctx context.Context) (T, error) {
+		if len(futures) == 0 {
+			return *new(T), ErrFutureEmpty
+		}
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		eg := NewErrorGroup()
+		for r := range futureResults(ctx, futures) {
+			if r.err == nil {
+				return r.value, nil
+			}
+			eg.Append(errorWithTag(r.err, futureTag(r.index)))
+		}
+		return *new(T), eg.ErrorOrNil()
+	})
+}
+
+// FutureRace returns a *Future that completes with the result of the first future
+// to complete, whether it succeeded or failed.
+func FutureRace[T any](ctx context.Context, futures ...*Future[T]) *Future[T] {
+	return Go(ctx, func(ctx context.Context) (T, error) {
+		if len(futures) == 0 {
+			return *new(T), ErrFutureEmpty
+		}
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		r := <-futureResults(ctx, futures)
+		return r.value, r.err
+	})
+}
+
+// FutureThen returns a *Future that runs the given function with the value of f once it
+// succeeds. If f fails, its error is returned without calling fn.
+//
+// Options apply to the new future, which includes waiting on f.
+func FutureThen[T any, U any](
+	ctx context.Context,
+	f *Future[T],
+	fn func(ctx context.Context, t T) (U, error),
+	options ...Option[*TaskConfig],
+) *Future[U] {
+	return Go(ctx, func(ctx context.Context) (U, error) {
+		v, err := f.Await(ctx)
+		if err != nil {
+			return *new(U), err
+		}
+		return fn(ctx, v)
+	}, options...)
+}
+
+// FutureMap returns a *Future with the result of the mapper applied to the value of f
+// once it succeeds. If f fails, its error is returned.
+func FutureMap[T any, U any](ctx context.Context, f *Future[T], mapper Mapper[T, U]) *Future[U] {
+	return FutureThen(ctx, f, func(_ context.Context, t T) (U, error) {
+		return mapper(t), nil
+	})
+}
+
+// futureResult is the result of a single future within a set.
+type futureResult[T any] struct {
+	index int
+	value T
+	err   error
+}
+
+// futureResults awaits all futures concurrently and returns a channel of their
+// results in order of completion. The channel is closed once all have been sent.
+func futureResults[T any](ctx context.Context, futures []*Future[T]) <-chan futureResult[T] {
+	results := make(chan futureResult[T], len(futures))
+	g, _ := NewTaskGroup(ctx)
+	for i, f := range futures {
+		g.Go(futureTag(i), func(ctx context.Context) error {
+			v, err := f.Await(ctx)
+			results <- futureResult[T]{index: i, value: v, err: err}
+			return nil
+		})
+	}
+	go func() {
+		_ = g.Wait()
+		close(results)
+	}()
+	return results
+}
+
+// futureTag returns the tag for the future at the given index.
+func futureTag(index int) string {
+	return fmt.Sprintf("future[%d]", index)
+}
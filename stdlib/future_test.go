@@ -0,0 +1,67 @@
+package stdlib_test
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ahawker/stdlibx-go/stdlib"
+	"github.com/ahawker/stdlibx-go/stdtest"
+)
+
+func TestFutureCombinators(t *testing.T) {
+	test := stdtest.NewTest(t)
+	ctx := test.Config.Context
+
+	value := func(v int) *stdlib.Future[int] {
+		return stdlib.Go(ctx, func(ctx context.Context) (int, error) { return v, nil })
+	}
+	failure := func(msg string) *stdlib.Future[int] {
+		return stdlib.Go(ctx, func(ctx context.Context) (int, error) { return 0, fmt.Errorf("%s", msg) })
+	}
+	blocked := func() *stdlib.Future[int] {
+		return stdlib.Go(ctx, func(ctx context.Context) (int, error) {
+			<-ctx.Done()
+			return 0, ctx.Err()
+		}, stdlib.WithTaskTimeout(time.Second))
+	}
+
+	test.Sub("all", func(t *stdtest.Test) {
+		values, err := stdlib.FutureAll(ctx, value(1), value(2), value(3)).Await(ctx)
+		t.OK(err)
+		t.Equal(values, []int{1, 2, 3})
+
+		_, err = stdlib.FutureAll(ctx, value(1), failure("a"), failure("b")).Await(ctx)
+		t.NotOK(err)
+		eg, ok := err.(*stdlib.ErrorGroup)
+		t.True(ok, "want *stdlib.ErrorGroup got %T", err)
+		t.Equal(eg.Len(), 2)
+	})
+	test.Sub("any", func(t *stdtest.Test) {
+		v, err := stdlib.FutureAny(ctx, failure("a"), value(2), blocked()).Await(ctx)
+		t.OK(err)
+		t.Equal(v, 2)
+
+		_, err = stdlib.FutureAny[int](ctx).Await(ctx)
+		t.EqualError(err, stdlib.ErrFutureEmpty)
+	})
+	test.Sub("race", func(t *stdtest.Test) {
+		_, err := stdlib.FutureRace(ctx, failure("a"), blocked()).Await(ctx)
+		t.NotOK(err)
+	})
+	test.Sub("then and map", func(t *stdtest.Test) {
+		f := stdlib.FutureMap(ctx, value(21), func(v int) int { return v * 2 })
+		s, err := stdlib.FutureThen(ctx, f, func(ctx context.Context, v int) (string, error) {
+			return strconv.Itoa(v), nil
+		}).Await(ctx)
+		t.OK(err)
+		t.Equal(s, "42")
+	})
+	test.Sub("result", func(t *stdtest.Test) {
+		f := blocked()
+		_, err := f.Result()
+		t.EqualError(err, stdlib.ErrFuturePending)
+	})
+}
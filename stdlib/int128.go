@@ -0,0 +1,281 @@
+package stdlib
+
+import (
+	"encoding"
+	"fmt"
+	"math"
+	"math/big"
+	"math/bits"
+	"strconv"
+)
+
+var (
+	_ fmt.Stringer             = Int128{}
+	_ encoding.TextMarshaler   = Int128{}
+	_ encoding.TextUnmarshaler = (*Int128)(nil)
+	_ fmt.Stringer             = Uint128{}
+	_ encoding.TextMarshaler   = Uint128{}
+	_ encoding.TextUnmarshaler = (*Uint128)(nil)
+)
+
+var (
+	// MaxInt128 is the largest Int128, 2^127 - 1.
+	MaxInt128 = Int128{Hi: math.MaxInt64, Lo: math.MaxUint64}
+	// MinInt128 is the smallest Int128, -2^127.
+	MinInt128 = Int128{Hi: 1 << 63}
+	// MaxUint128 is the largest Uint128, 2^128 - 1.
+	MaxUint128 = Uint128{Hi: math.MaxUint64, Lo: math.MaxUint64}
+)
+
+// Uint128 is an unsigned 128-bit integer of two uint64 halves.
+//
+// Arithmetic wraps around on overflow, like the builtin unsigned integers.
+type Uint128 struct {
+	// Hi is the high 64 bits.
+	Hi uint64
+	// Lo is the low 64 bits.
+	Lo uint64
+}
+
+// Uint128From64 returns the Uint128 of the uint64.
+func Uint128From64(v uint64) Uint128 {
+	return Uint128{Lo: v}
+}
+
+// Uint128FromBig returns the Uint128 of the big.Int and ErrPrecisionLoss if it is out of range.
+func Uint128FromBig(b *big.Int) (Uint128, error) {
+	if b.Sign() < 0 || b.BitLen() > 128 {
+		return Uint128{}, ErrPrecisionLoss.Wrapf("from=*big.Int to=uint128 value=%s", b)
+	}
+	lo := new(big.Int).And(b, new(big.Int).SetUint64(math.MaxUint64))
+	hi := new(big.Int).Rsh(b, 64)
+	return Uint128{Hi: hi.Uint64(), Lo: lo.Uint64()}, nil
+}
+
+// ParseUint128 parses the string as an integer like 'ToNumber', e.g. "0xFF" or "1_000".
+// Only 0x, 0o and 0b prefixes change the base, so "010" is 10.
+func ParseUint128(s string) (Uint128, error) {
+	b, ok := toNumberBigInt(s)
+	if !ok {
+		return Uint128{}, ErrTypeConversionFailed.Wrapf("value=%q desired_type=uint128", s)
+	}
+	return Uint128FromBig(b)
+}
+
+// IsZero returns true if the value is zero.
+func (u Uint128) IsZero() bool {
+	return u.Hi == 0 && u.Lo == 0
+}
+
+// Cmp returns -1, 0 or +1 if the value is less than, equal to or greater than v.
+func (u Uint128) Cmp(v Uint128) int {
+	switch {
+	case u.Hi < v.Hi || (u.Hi == v.Hi && u.Lo < v.Lo):
+		return -1
+	case u == v:
+		return 0
+	default:
+		return 1
+	}
+}
+
+// Add returns u + v.
+func (u Uint128) Add(v Uint128) Uint128 {
+	lo, carry := bits.Add64(u.Lo, v.Lo, 0)
+	hi, _ := bits.Add64(u.Hi, v.Hi, carry)
+	return Uint128{Hi: hi, Lo: lo}
+}
+
+// Sub returns u - v.
+func (u Uint128) Sub(v Uint128) Uint128 {
+	lo, borrow := bits.Sub64(u.Lo, v.Lo, 0)
+	hi, _ := bits.Sub64(u.Hi, v.Hi, borrow)
+	return Uint128{Hi: hi, Lo: lo}
+}
+
+// Mul returns u * v.
+func (u Uint128) Mul(v Uint128) Uint128 {
+	hi, lo := bits.Mul64(u.Lo, v.Lo)
+	hi += u.Hi*v.Lo + u.Lo*v.Hi
+	return Uint128{Hi: hi, Lo: lo}
+}
+
+// QuoRem returns the quotient and remainder of u / v. It panics if v is zero.
+func (u Uint128) QuoRem(v Uint128) (Uint128, Uint128) {
+	q, r := new(big.Int).QuoRem(u.Big(), v.Big(), new(big.Int))
+	quo, _ := Uint128FromBig(q)
+	rem, _ := Uint128FromBig(r)
+	return quo, rem
+}
+
+// Big returns the value as a big.Int.
+func (u Uint128) Big() *big.Int {
+	b := new(big.Int).SetUint64(u.Hi)
+	b.Lsh(b, 64)
+	return b.Or(b, new(big.Int).SetUint64(u.Lo))
+}
+
+// String returns the decimal representation.
+//
+// Interface: fmt.Stringer.
+func (u Uint128) String() string {
+	if u.Hi == 0 {
+		return strconv.FormatUint(u.Lo, 10)
+	}
+	return u.Big().String()
+}
+
+// MarshalText encodes the value as a decimal string.
+//
+// Interface: encoding.TextMarshaler.
+func (u Uint128) MarshalText() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+
+// UnmarshalText decodes the value with ParseUint128.
+//
+// Interface: encoding.TextUnmarshaler.
+func (u *Uint128) UnmarshalText(text []byte) error {
+	v, err := ParseUint128(string(text))
+	if err != nil {
+		return err
+	}
+	*u = v
+	return nil
+}
+
+// Int128 is a signed, two's complement 128-bit integer of two uint64 halves.
+//
+// Arithmetic wraps around on overflow, like the builtin signed integers.
+type Int128 struct {
+	// Hi is the high 64 bits, including the sign bit.
+	Hi uint64
+	// Lo is the low 64 bits.
+	Lo uint64
+}
+
+// Int128From64 returns the Int128 of the int64.
+func Int128From64(v int64) Int128 {
+	return Int128{Hi: uint64(v >> 63), Lo: uint64(v)}
+}
+
+// Int128FromBig returns the Int128 of the big.Int and ErrPrecisionLoss if it is out of range.
+func Int128FromBig(b *big.Int) (Int128, error) {
+	if b.Cmp(MinInt128.Big()) < 0 || b.Cmp(MaxInt128.Big()) > 0 {
+		return Int128{}, ErrPrecisionLoss.Wrapf("from=*big.Int to=int128 value=%s", b)
+	}
+	u, _ := Uint128FromBig(new(big.Int).Abs(b))
+	i := Int128(u)
+	if b.Sign() < 0 {
+		i = i.Neg()
+	}
+	return i, nil
+}
+
+// ParseInt128 parses the string as an integer like 'ToNumber', e.g. "-0xFF" or "1_000".
+// Only 0x, 0o and 0b prefixes change the base, so "010" is 10.
+func ParseInt128(s string) (Int128, error) {
+	b, ok := toNumberBigInt(s)
+	if !ok {
+		return Int128{}, ErrTypeConversionFailed.Wrapf("value=%q desired_type=int128", s)
+	}
+	return Int128FromBig(b)
+}
+
+// IsZero returns true if the value is zero.
+func (i Int128) IsZero() bool {
+	return i.Hi == 0 && i.Lo == 0
+}
+
+// Sign returns -1, 0 or +1 if the value is negative, zero or positive.
+func (i Int128) Sign() int {
+	switch {
+	case int64(i.Hi) < 0:
+		return -1
+	case i.IsZero():
+		return 0
+	default:
+		return 1
+	}
+}
+
+// Cmp returns -1, 0 or +1 if the value is less than, equal to or greater than v.
+func (i Int128) Cmp(v Int128) int {
+	switch {
+	case int64(i.Hi) < int64(v.Hi) || (i.Hi == v.Hi && i.Lo < v.Lo):
+		return -1
+	case i == v:
+		return 0
+	default:
+		return 1
+	}
+}
+
+// Neg returns -i.
+func (i Int128) Neg() Int128 {
+	return Int128(Uint128{}.Sub(Uint128(i)))
+}
+
+// Add returns i + v.
+func (i Int128) Add(v Int128) Int128 {
+	return Int128(Uint128(i).Add(Uint128(v)))
+}
+
+// Sub returns i - v.
+func (i Int128) Sub(v Int128) Int128 {
+	return Int128(Uint128(i).Sub(Uint128(v)))
+}
+
+// Mul returns i * v.
+func (i Int128) Mul(v Int128) Int128 {
+	return Int128(Uint128(i).Mul(Uint128(v)))
+}
+
+// QuoRem returns the quotient and remainder of i / v, truncated towards zero.
+// It panics if v is zero.
+func (i Int128) QuoRem(v Int128) (Int128, Int128) {
+	if i == MinInt128 && v == Int128From64(-1) {
+		return MinInt128, Int128{}
+	}
+	q, r := new(big.Int).QuoRem(i.Big(), v.Big(), new(big.Int))
+	quo, _ := Int128FromBig(q)
+	rem, _ := Int128FromBig(r)
+	return quo, rem
+}
+
+// Big returns the value as a big.Int.
+func (i Int128) Big() *big.Int {
+	if i.Sign() >= 0 {
+		return Uint128(i).Big()
+	}
+	return new(big.Int).Neg(Uint128(i.Neg()).Big())
+}
+
+// String returns the decimal representation.
+//
+// Interface: fmt.Stringer.
+func (i Int128) String() string {
+	if i.Hi == uint64(int64(i.Lo)>>63) {
+		return strconv.FormatInt(int64(i.Lo), 10)
+	}
+	return i.Big().String()
+}
+
+// MarshalText encodes the value as a decimal string.
+//
+// Interface: encoding.TextMarshaler.
+func (i Int128) MarshalText() ([]byte, error) {
+	return []byte(i.String()), nil
+}
+
+// UnmarshalText decodes the value with ParseInt128.
+//
+// Interface: encoding.TextUnmarshaler.
+func (i *Int128) UnmarshalText(text []byte) error {
+	v, err := ParseInt128(string(text))
+	if err != nil {
+		return err
+	}
+	*i = v
+	return nil
+}
@@ -0,0 +1,80 @@
+package stdlib_test
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ahawker/stdlibx-go/stdlib"
+	"github.com/ahawker/stdlibx-go/stdtest"
+)
+
+func TestUint128(t *testing.T) {
+	test := stdtest.NewTest(t)
+
+	max64 := stdlib.Uint128From64(math.MaxUint64)
+	sum := max64.Add(stdlib.Uint128From64(1))
+	test.Equal(sum, stdlib.Uint128{Hi: 1})
+	test.Equal(sum.String(), "18446744073709551616")
+	test.Equal(sum.Sub(stdlib.Uint128From64(1)), max64)
+	test.Equal(stdlib.MaxUint128.Add(stdlib.Uint128From64(1)), stdlib.Uint128{})
+	test.Equal(max64.Mul(max64).String(), new(big.Int).Mul(max64.Big(), max64.Big()).String())
+	test.Equal(sum.Cmp(max64), 1)
+
+	q, r := stdlib.MaxUint128.QuoRem(stdlib.Uint128From64(10))
+	test.Equal(q.String(), "34028236692093846346337460743176821145")
+	test.Equal(r, stdlib.Uint128From64(5))
+
+	u, err := stdlib.ParseUint128("0xFFFF_FFFF_FFFF_FFFF_FFFF_FFFF_FFFF_FFFF")
+	test.OK(err)
+	test.Equal(u, stdlib.MaxUint128)
+	_, err = stdlib.ParseUint128("340282366920938463463374607431768211456")
+	test.True(errors.Is(err, stdlib.ErrPrecisionLoss), "want ErrPrecisionLoss got %v", err)
+	_, err = stdlib.ParseUint128("-1")
+	test.True(errors.Is(err, stdlib.ErrPrecisionLoss), "want ErrPrecisionLoss got %v", err)
+	_, err = stdlib.ParseUint128("one")
+	test.True(errors.Is(err, stdlib.ErrTypeConversionFailed), "want ErrTypeConversionFailed got %v", err)
+}
+
+func TestInt128(t *testing.T) {
+	test := stdtest.NewTest(t)
+
+	minus := stdlib.Int128From64(-1)
+	test.Equal(minus, stdlib.Int128{Hi: math.MaxUint64, Lo: math.MaxUint64})
+	test.Equal(minus.String(), "-1")
+	test.Equal(minus.Sign(), -1)
+	test.Equal(minus.Add(stdlib.Int128From64(1)), stdlib.Int128{})
+	test.Equal(stdlib.MaxInt128.Add(stdlib.Int128From64(1)), stdlib.MinInt128)
+	test.Equal(stdlib.MinInt128.String(), "-170141183460469231731687303715884105728")
+	test.Equal(stdlib.MinInt128.Neg(), stdlib.MinInt128)
+	test.Equal(stdlib.MinInt128.Cmp(stdlib.MaxInt128), -1)
+	test.Equal(stdlib.Int128From64(-6).Mul(stdlib.Int128From64(7)), stdlib.Int128From64(-42))
+
+	q, r := stdlib.Int128From64(-7).QuoRem(stdlib.Int128From64(2))
+	test.Equal(q, stdlib.Int128From64(-3))
+	test.Equal(r, stdlib.Int128From64(-1))
+	q, _ = stdlib.MinInt128.QuoRem(minus)
+	test.Equal(q, stdlib.MinInt128)
+
+	i, err := stdlib.ParseInt128("-170141183460469231731687303715884105728")
+	test.OK(err)
+	test.Equal(i, stdlib.MinInt128)
+	_, err = stdlib.ParseInt128("170141183460469231731687303715884105728")
+	test.True(errors.Is(err, stdlib.ErrPrecisionLoss), "want ErrPrecisionLoss got %v", err)
+
+	var text stdlib.Int128
+	test.OK(text.UnmarshalText([]byte("-0x10")))
+	test.Equal(text, stdlib.Int128From64(-16))
+
+	// Leading zeros are decimal, as in ToNumber.
+	i, err = stdlib.ParseInt128("-010")
+	test.OK(err)
+	test.Equal(i, stdlib.Int128From64(-10))
+	i, err = stdlib.ParseInt128("1_000")
+	test.OK(err)
+	test.Equal(i, stdlib.Int128From64(1000))
+	u, err := stdlib.ParseUint128("0o10")
+	test.OK(err)
+	test.Equal(u, stdlib.Uint128From64(8))
+}
@@ -0,0 +1,267 @@
+package stdlib
+
+import "iter"
+
+// RangerSeq returns an iter.Seq over the items of the Ranger.
+func RangerSeq[T any](r Ranger[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		r.Range(yield)
+	}
+}
+
+// KeyedRangerSeq2 returns an iter.Seq2 over the key/value items of the KeyedRanger.
+func KeyedRangerSeq2[K comparable, V any](r KeyedRanger[K, V]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		r.Range(yield)
+	}
+}
+
+// SeqRanger returns a Ranger over the items of the iter.Seq.
+func SeqRanger[T any](seq iter.Seq[T]) Ranger[T] {
+	return rangerFn[T](func(predicate Predicate[T]) {
+		seq(predicate)
+	})
+}
+
+// Seq2KeyedRanger returns a KeyedRanger over the key/value items of the iter.Seq2.
+func Seq2KeyedRanger[K comparable, V any](seq iter.Seq2[K, V]) KeyedRanger[K, V] {
+	return keyedRangerFn[K, V](func(predicate KeyedPredicate[K, V]) {
+		seq(predicate)
+	})
+}
+
+// SeqMap returns a sequence of the mapper applied to each item.
+func SeqMap[TIn any, TOut any](seq iter.Seq[TIn], mapper Mapper[TIn, TOut]) iter.Seq[TOut] {
+	return func(yield func(TOut) bool) {
+		for t := range seq {
+			if !yield(mapper(t)) {
+				return
+			}
+		}
+	}
+}
+
+// SeqMap2 returns a sequence of the keyed mapper applied to each value.
+func SeqMap2[K comparable, VIn any, VOut any](seq iter.Seq2[K, VIn], mapper KeyedMapper[K, VIn, VOut]) iter.Seq2[K, VOut] {
+	return func(yield func(K, VOut) bool) {
+		for k, v := range seq {
+			if !yield(k, mapper(k, v)) {
+				return
+			}
+		}
+	}
+}
+
+// SeqFilter returns a sequence of only the items that match the predicate.
+func SeqFilter[T any](seq iter.Seq[T], predicate Predicate[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for t := range seq {
+			if predicate(t) && !yield(t) {
+				return
+			}
+		}
+	}
+}
+
+// SeqFilter2 returns a sequence of only the key/value items that match the predicate.
+func SeqFilter2[K comparable, V any](seq iter.Seq2[K, V], predicate KeyedPredicate[K, V]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, v := range seq {
+			if predicate(k, v) && !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// SeqFlatMap returns a sequence of the items of every sequence returned by the mapper.
+func SeqFlatMap[TIn any, TOut any](seq iter.Seq[TIn], mapper Mapper[TIn, iter.Seq[TOut]]) iter.Seq[TOut] {
+	return func(yield func(TOut) bool) {
+		for t := range seq {
+			for out := range mapper(t) {
+				if !yield(out) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// SeqTake returns a sequence of at most the first n items.
+func SeqTake[T any](seq iter.Seq[T], n int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if n <= 0 {
+			return
+		}
+		i := 0
+		for t := range seq {
+			if !yield(t) {
+				return
+			}
+			if i++; i >= n {
+				return
+			}
+		}
+	}
+}
+
+// SeqSkip returns a sequence of all items after the first n.
+func SeqSkip[T any](seq iter.Seq[T], n int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		i := 0
+		for t := range seq {
+			if i++; i <= n {
+				continue
+			}
+			if !yield(t) {
+				return
+			}
+		}
+	}
+}
+
+// SeqTakeWhile returns a sequence of the leading items that match the predicate.
+func SeqTakeWhile[T any](seq iter.Seq[T], predicate Predicate[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for t := range seq {
+			if !predicate(t) || !yield(t) {
+				return
+			}
+		}
+	}
+}
+
+// SeqChunk returns a sequence of consecutive, non-overlapping slices of up to
+// size items. The last chunk may be shorter.
+func SeqChunk[T any](seq iter.Seq[T], size int) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		if size <= 0 {
+			return
+		}
+		chunk := make([]T, 0, size)
+		for t := range seq {
+			chunk = append(chunk, t)
+			if len(chunk) < size {
+				continue
+			}
+			if !yield(chunk) {
+				return
+			}
+			chunk = make([]T, 0, size)
+		}
+		if len(chunk) > 0 {
+			yield(chunk)
+		}
+	}
+}
+
+// SeqWindow returns a sequence of overlapping slices of size consecutive items,
+// advancing one item at a time.
+func SeqWindow[T any](seq iter.Seq[T], size int) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		if size <= 0 {
+			return
+		}
+		window := make([]T, 0, size)
+		for t := range seq {
+			if len(window) == size {
+				window = window[1:]
+			}
+			window = append(window, t)
+			if len(window) < size {
+				continue
+			}
+			out := make([]T, size)
+			copy(out, window)
+			if !yield(out) {
+				return
+			}
+		}
+	}
+}
+
+// SeqZip returns a sequence of pairs of items from both sequences, stopping
+// when either is exhausted.
+func SeqZip[A any, B any](a iter.Seq[A], b iter.Seq[B]) iter.Seq2[A, B] {
+	return func(yield func(A, B) bool) {
+		next, stop := iter.Pull(b)
+		defer stop()
+
+		for va := range a {
+			vb, ok := next()
+			if !ok || !yield(va, vb) {
+				return
+			}
+		}
+	}
+}
+
+// SeqEnumerate returns a sequence of items paired with their index.
+func SeqEnumerate[T any](seq iter.Seq[T]) iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		i := 0
+		for t := range seq {
+			if !yield(i, t) {
+				return
+			}
+			i++
+		}
+	}
+}
+
+// SeqConcat returns a sequence of the items of all sequences in order.
+func SeqConcat[T any](seqs ...iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, seq := range seqs {
+			for t := range seq {
+				if !yield(t) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// SeqDedupe returns a sequence of the first occurrence of each item.
+func SeqDedupe[T comparable](seq iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		seen := make(map[T]struct{})
+		for t := range seq {
+			if _, ok := seen[t]; ok {
+				continue
+			}
+			seen[t] = struct{}{}
+			if !yield(t) {
+				return
+			}
+		}
+	}
+}
+
+// SeqReduce combines all items into a single value, starting with initial.
+func SeqReduce[T any, A any](seq iter.Seq[T], initial A, fn func(acc A, t T) A) A {
+	acc := initial
+	for t := range seq {
+		acc = fn(acc, t)
+	}
+	return acc
+}
+
+// SeqCollect returns a slice of all items in the sequence.
+func SeqCollect[T any](seq iter.Seq[T]) []T {
+	var output []T
+	for t := range seq {
+		output = append(output, t)
+	}
+	return output
+}
+
+// SeqCollectMap returns a map of all key/value items in the sequence. Later
+// values overwrite earlier ones for the same key.
+func SeqCollectMap[K comparable, V any](seq iter.Seq2[K, V]) map[K]V {
+	output := make(map[K]V)
+	for k, v := range seq {
+		output[k] = v
+	}
+	return output
+}
@@ -0,0 +1,67 @@
+package stdlib_test
+
+import (
+	"iter"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/ahawker/stdlibx-go/stdlib"
+	"github.com/ahawker/stdlibx-go/stdtest"
+)
+
+func TestSeq(t *testing.T) {
+	test := stdtest.NewTest(t)
+
+	numbers := func(n int) iter.Seq[int] {
+		return func(yield func(int) bool) {
+			for i := 1; i <= n; i++ {
+				if !yield(i) {
+					return
+				}
+			}
+		}
+	}
+	even := func(i int) bool { return i%2 == 0 }
+
+	test.Equal(stdlib.SeqCollect(stdlib.SeqMap(numbers(3), strconv.Itoa)), []string{"1", "2", "3"})
+	test.Equal(stdlib.SeqCollect(stdlib.SeqFilter(numbers(6), even)), []int{2, 4, 6})
+	test.Equal(stdlib.SeqCollect(stdlib.SeqFlatMap(numbers(3), numbers)), []int{1, 1, 2, 1, 2, 3})
+	test.Equal(stdlib.SeqCollect(stdlib.SeqTake(numbers(100), 3)), []int{1, 2, 3})
+	test.Equal(stdlib.SeqCollect(stdlib.SeqSkip(numbers(5), 3)), []int{4, 5})
+	test.Equal(stdlib.SeqCollect(stdlib.SeqTakeWhile(numbers(5), func(i int) bool { return i < 3 })), []int{1, 2})
+	test.Equal(stdlib.SeqCollect(stdlib.SeqChunk(numbers(5), 2)), [][]int{{1, 2}, {3, 4}, {5}})
+	test.Equal(stdlib.SeqCollect(stdlib.SeqWindow(numbers(4), 2)), [][]int{{1, 2}, {2, 3}, {3, 4}})
+	test.Equal(stdlib.SeqCollect(stdlib.SeqConcat(numbers(2), numbers(1))), []int{1, 2, 1})
+	test.Equal(stdlib.SeqCollect(stdlib.SeqDedupe(stdlib.SeqConcat(numbers(2), numbers(3)))), []int{1, 2, 3})
+	test.Equal(stdlib.SeqReduce(numbers(4), 0, func(acc, i int) int { return acc + i }), 10)
+	test.Equal(
+		stdlib.SeqCollectMap(stdlib.SeqZip(stdlib.SeqMap(numbers(3), strconv.Itoa), numbers(2))),
+		map[string]int{"1": 1, "2": 2},
+	)
+	test.Equal(
+		stdlib.SeqCollectMap(stdlib.SeqEnumerate(slices.Values([]string{"a", "b"}))),
+		map[int]string{0: "a", 1: "b"},
+	)
+	test.Equal(
+		stdlib.SeqCollectMap(stdlib.SeqMap2(stdlib.SeqEnumerate(numbers(2)), func(k, v int) int { return k * v })),
+		map[int]int{0: 0, 1: 2},
+	)
+
+	// Infinite sequences are evaluated lazily.
+	naturals := func(yield func(int) bool) {
+		for i := 0; ; i++ {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+	test.Equal(stdlib.SeqCollect(stdlib.SeqTake(stdlib.SeqFilter(naturals, even), 3)), []int{0, 2, 4})
+
+	// Rangers adapt to and from sequences.
+	ranger := stdlib.SeqRanger(numbers(4))
+	test.Equal(stdlib.SliceFilterRange(ranger, even), []int{2, 4})
+	keyed := stdlib.Seq2KeyedRanger(stdlib.SeqEnumerate(numbers(4)))
+	test.Equal(stdlib.MapFilterRange(keyed, func(k, v int) bool { return k < 2 }), map[int]int{0: 1, 1: 2})
+	test.Equal(stdlib.SeqCollect(stdlib.SeqTake(stdlib.RangerSeq(ranger), 2)), []int{1, 2})
+}
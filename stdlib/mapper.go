@@ -1,8 +1,63 @@
 package stdlib
 
+import "fmt"
+
 // Mapper describes a 'map' function applied to another type.
 type Mapper[TIn any, TOut any] func(t TIn) TOut
 
 // KeyedMapper describes a 'map' function for a given
 // key/value input.
 type KeyedMapper[K comparable, VIn any, VOut any] func(k K, v VIn) VOut
+
+// MapperE describes a 'map' function applied to another type that can fail.
+type MapperE[TIn any, TOut any] func(t TIn) (TOut, error)
+
+// KeyedMapperE describes a 'map' function for a given
+// key/value input that can fail.
+type KeyedMapperE[K comparable, VIn any, VOut any] func(k K, v VIn) (VOut, error)
+
+// TransformConfig for error-returning transforms, e.g. 'SliceMapE'.
+type TransformConfig struct {
+	// FailFast stops at the first error instead of collecting all errors.
+	FailFast bool
+}
+
+// WithTransformFailFast sets if transforms stop at the first error.
+func WithTransformFailFast(failFast bool) Option[*TransformConfig] {
+	return func(o *TransformConfig) error {
+		o.FailFast = failFast
+		return nil
+	}
+}
+
+// newTransformErrors creates a new *transformErrors for the options.
+func newTransformErrors(options []Option[*TransformConfig]) (*transformErrors, error) {
+	cfg, err := OptionApply(&TransformConfig{}, options...)
+	if err != nil {
+		return nil, err
+	}
+	return &transformErrors{config: cfg, errors: NewErrorGroup()}, nil
+}
+
+// transformErrors collects the errors of an error-returning transform.
+type transformErrors struct {
+	config *TransformConfig
+	errors *ErrorGroup
+}
+
+// append records the error tagged with the item that failed and reports
+// whether the transform should continue.
+func (t *transformErrors) append(err error, tag string) bool {
+	t.errors.Append(errorWithTag(err, tag))
+	return !t.config.FailFast
+}
+
+// indexTag returns the tag for a failed slice item.
+func indexTag(i int) string {
+	return fmt.Sprintf("index[%d]", i)
+}
+
+// keyTag returns the tag for a failed map item.
+func keyTag[K comparable](k K) string {
+	return fmt.Sprintf("key[%v]", k)
+}
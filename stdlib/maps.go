@@ -12,17 +12,78 @@ func MapFilter[K comparable, V any](input map[K]V, predicate KeyedPredicate[K, V
 	return filtered
 }
 
-// MapFilterRange will return a new map containing only items
-// from the input keyed ranger that match the predicate function.
-func MapFilterRange[K comparable, V any](input KeyedRanger[K, V], predicate KeyedPredicate[K, V]) map[K]V {
+// MapFilterE will return a new map containing only items from the input
+// map that match the predicate function or the errors tagged with the key
+// of the items that failed.
+func MapFilterE[K comparable, V any](
+	input map[K]V,
+	predicate KeyedPredicateE[K, V],
+	options ...Option[*TransformConfig],
+) (map[K]V, error) {
+	errs, err := newTransformErrors(options)
+	if err != nil {
+		return nil, err
+	}
 	filtered := make(map[K]V)
-	input.Range(func(key K, val V) bool {
-		if predicate(key, val) {
+	for key, val := range input {
+		ok, err := predicate(key, val)
+		if err != nil {
+			if !errs.append(err, keyTag(key)) {
+				break
+			}
+			continue
+		}
+		if ok {
 			filtered[key] = val
 		}
-		return true
-	})
-	return filtered
+	}
+	if err := errs.errors.ErrorOrNil(); err != nil {
+		return nil, err
+	}
+	return filtered, nil
+}
+
+// MapMap returns a map with the values replaced by the results from the given 'map' function.
+func MapMap[K comparable, VIn any, VOut any](input map[K]VIn, mapper KeyedMapper[K, VIn, VOut]) map[K]VOut {
+	output := make(map[K]VOut, len(input))
+	for key, val := range input {
+		output[key] = mapper(key, val)
+	}
+	return output
+}
+
+// MapMapE returns a map with the values replaced by the results from the given
+// 'map' function or the errors tagged with the key of the items that failed.
+func MapMapE[K comparable, VIn any, VOut any](
+	input map[K]VIn,
+	mapper KeyedMapperE[K, VIn, VOut],
+	options ...Option[*TransformConfig],
+) (map[K]VOut, error) {
+	errs, err := newTransformErrors(options)
+	if err != nil {
+		return nil, err
+	}
+	output := make(map[K]VOut, len(input))
+	for key, val := range input {
+		out, err := mapper(key, val)
+		if err != nil {
+			if !errs.append(err, keyTag(key)) {
+				break
+			}
+			continue
+		}
+		output[key] = out
+	}
+	if err := errs.errors.ErrorOrNil(); err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+// MapFilterRange will return a new map containing only items
+// from the input keyed ranger that match the predicate function.
+func MapFilterRange[K comparable, V any](input KeyedRanger[K, V], predicate KeyedPredicate[K, V]) map[K]V {
+	return SeqCollectMap(SeqFilter2(KeyedRangerSeq2(input), predicate))
 }
 
 // MapKeys returns a slice of all keys for the map.
@@ -0,0 +1,438 @@
+//go:generate go-enum --marshal --names
+package stdlib
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ErrMapPathInvalid is returned when a path expression cannot be parsed.
+var ErrMapPathInvalid = Error{
+	Code:      "map_path_invalid",
+	Message:   "map path expression is invalid",
+	Namespace: ErrorNamespaceDefault,
+}
+
+// ErrMapPathNotFound is returned when a path does not exist in a map.
+var ErrMapPathNotFound = Error{
+	Code:      "map_path_not_found",
+	Message:   "map path does not exist",
+	Namespace: ErrorNamespaceDefault,
+}
+
+// ErrMapPathType is returned when a path traverses a value that is not a
+// map or slice as required by the path expression.
+var ErrMapPathType = Error{
+	Code:      "map_path_type",
+	Message:   "map path traverses a value of the wrong type",
+	Namespace: ErrorNamespaceDefault,
+}
+
+// ErrMapMergeConflict is returned when merging maps with different values for
+// the same path using the 'error' merge strategy.
+var ErrMapMergeConflict = Error{
+	Code:      "map_merge_conflict",
+	Message:   "maps have conflicting values for the same path",
+	Namespace: ErrorNamespaceDefault,
+}
+
+// MapMergeStrategy is the policy applied when merged maps have different values
+// for the same path. Nested maps are always merged.
+//
+// override: Use the value from the later map.
+// keep: Keep the value from the earlier map.
+// append: Concatenate slices, otherwise use the value from the later map.
+// error: Return an error.
+//
+// ENUM(override, keep, append, error).
+type MapMergeStrategy string
+
+// MapChangeType is the kind of difference found at a path.
+//
+// ENUM(added, removed, changed).
+type MapChangeType string
+
+// MapChange is a difference between two maps at a path.
+type MapChange struct {
+	// Path of the value, e.g. 'a.b[2].c'.
+	Path string
+	// Type of the change.
+	Type MapChangeType
+	// Old value. Nil when added.
+	Old any
+	// New value. Nil when removed.
+	New any
+}
+
+// MapMerge returns a deep copy of all maps merged in order, using the strategy
+// to resolve conflicting values.
+func MapMerge(strategy MapMergeStrategy, input ...map[string]any) (map[string]any, error) {
+	if !strategy.IsValid() {
+		return nil, ErrInvalidMapMergeStrategy
+	}
+	output := make(map[string]any)
+	for _, m := range input {
+		if err := mapMerge(output, m, "", strategy); err != nil {
+			return nil, err
+		}
+	}
+	return output, nil
+}
+
+// MapDiff returns the paths added, removed or changed from a to b, sorted by path.
+//
+// Nested maps are compared key by key while all other values are compared whole.
+func MapDiff(a map[string]any, b map[string]any) []MapChange {
+	var changes []MapChange
+	mapDiff(&changes, "", a, b)
+	slices.SortFunc(changes, func(x, y MapChange) int {
+		return strings.Compare(x.Path, y.Path)
+	})
+	return changes
+}
+
+// MapFlatten returns a single level map keyed by the path of every leaf value,
+// e.g. 'a.b[2].c'. Empty maps and slices are kept as leaves.
+func MapFlatten(input map[string]any) map[string]any {
+	output := make(map[string]any)
+	for k, v := range input {
+		mapFlatten(output, mapPathKey("", k), v)
+	}
+	return output
+}
+
+// MapUnflatten returns the nested map for a map keyed by paths, reversing 'MapFlatten'.
+func MapUnflatten(input map[string]any) (map[string]any, error) {
+	output := make(map[string]any)
+	for _, path := range slices.Sorted(maps.Keys(input)) {
+		if err := MapPathSet(output, path, input[path]); err != nil {
+			return nil, err
+		}
+	}
+	return output, nil
+}
+
+// MapPathGet returns the value at the path, e.g. 'a.b[2].c'.
+func MapPathGet(input map[string]any, path string) (any, error) {
+	segments, err := parseMapPath(path)
+	if err != nil {
+		return nil, err
+	}
+	return mapPathGet(input, segments)
+}
+
+// MapPathSet sets the value at the path, creating intermediate maps and
+// growing slices as needed. The input map must not be nil.
+func MapPathSet(input map[string]any, path string, value any) error {
+	segments, err := parseMapPath(path)
+	if err != nil {
+		return err
+	}
+	if input == nil {
+		return ErrMapPathType.Wrapf("path=%s value_type=nil desired_type=map[string]any", formatMapPath(segments[:1]))
+	}
+	_, err = mapPathSet(input, segments, 0, value)
+	return err
+}
+
+// MapPathDelete removes the value at the path. Slice items after a removed
+// index are shifted down.
+func MapPathDelete(input map[string]any, path string) error {
+	segments, err := parseMapPath(path)
+	if err != nil {
+		return err
+	}
+
+	parentPath, last := segments[:len(segments)-1], segments[len(segments)-1]
+	parent, err := mapPathGet(input, parentPath)
+	if err != nil {
+		return err
+	}
+	if _, err := mapPathChild(parent, segments, len(segments)-1); err != nil {
+		return err
+	}
+
+	if !last.isIndex {
+		delete(parent.(map[string]any), last.key)
+		return nil
+	}
+	items := parent.([]any)
+	_, err = mapPathSet(input, parentPath, 0, slices.Delete(slices.Clone(items), last.index, last.index+1))
+	return err
+}
+
+// mapMerge merges src into dst, which is owned by the caller.
+func mapMerge(dst map[string]any, src map[string]any, prefix string, strategy MapMergeStrategy) error {
+	for _, k := range slices.Sorted(maps.Keys(src)) {
+		sv, path := src[k], mapPathKey(prefix, k)
+		dv, ok := dst[k]
+		if !ok {
+			dst[k] = mapDeepCopy(sv)
+			continue
+		}
+
+		dm, dok := dv.(map[string]any)
+		sm, sok := sv.(map[string]any)
+		if dok && sok {
+			if err := mapMerge(dm, sm, path, strategy); err != nil {
+				return err
+			}
+			continue
+		}
+		if reflect.DeepEqual(dv, sv) {
+			continue
+		}
+
+		switch strategy {
+		case MapMergeStrategyKeep:
+		case MapMergeStrategyAppend:
+			ds, dok := dv.([]any)
+			ss, sok := sv.([]any)
+			if dok && sok {
+				dst[k] = append(ds, mapDeepCopy(ss).([]any)...)
+			} else {
+				dst[k] = mapDeepCopy(sv)
+			}
+		case MapMergeStrategyError:
+			return ErrMapMergeConflict.Wrapf("path=%s", path)
+		default:
+			dst[k] = mapDeepCopy(sv)
+		}
+	}
+	return nil
+}
+
+// mapDeepCopy returns a copy of nested maps and slices.
+func mapDeepCopy(v any) any {
+	switch c := v.(type) {
+	case map[string]any:
+		output := make(map[string]any, len(c))
+		for k, v := range c {
+			output[k] = mapDeepCopy(v)
+		}
+		return output
+	case []any:
+		output := make([]any, len(c))
+		for i, v := range c {
+			output[i] = mapDeepCopy(v)
+		}
+		return output
+	default:
+		return v
+	}
+}
+
+// mapDiff appends the changes from a to b.
+func mapDiff(changes *[]MapChange, prefix string, a map[string]any, b map[string]any) {
+	for k, av := range a {
+		path := mapPathKey(prefix, k)
+		bv, ok := b[k]
+		if !ok {
+			*changes = append(*changes, MapChange{Path: path, Type: MapChangeTypeRemoved, Old: av})
+			continue
+		}
+		am, aok := av.(map[string]any)
+		bm, bok := bv.(map[string]any)
+		switch {
+		case aok && bok:
+			mapDiff(changes, path, am, bm)
+		case !reflect.DeepEqual(av, bv):
+			*changes = append(*changes, MapChange{Path: path, Type: MapChangeTypeChanged, Old: av, New: bv})
+		}
+	}
+	for k, bv := range b {
+		if _, ok := a[k]; !ok {
+			*changes = append(*changes, MapChange{Path: mapPathKey(prefix, k), Type: MapChangeTypeAdded, New: bv})
+		}
+	}
+}
+
+// mapFlatten adds the leaf values of v to output.
+func mapFlatten(output map[string]any, path string, v any) {
+	switch c := v.(type) {
+	case map[string]any:
+		if len(c) == 0 {
+			output[path] = c
+		}
+		for k, v := range c {
+			mapFlatten(output, mapPathKey(path, k), v)
+		}
+	case []any:
+		if len(c) == 0 {
+			output[path] = c
+		}
+		for i, v := range c {
+			mapFlatten(output, mapPathIndex(path, i), v)
+		}
+	default:
+		output[path] = v
+	}
+}
+
+// mapPathGet returns the value at the path segments.
+func mapPathGet(input map[string]any, segments []mapPathSegment) (any, error) {
+	var (
+		current any = input
+		err     error
+	)
+	for i := range segments {
+		if current, err = mapPathChild(current, segments, i); err != nil {
+			return nil, err
+		}
+	}
+	return current, nil
+}
+
+// mapPathChild returns the child of v for the segment at index i.
+func mapPathChild(v any, segments []mapPathSegment, i int) (any, error) {
+	segment := segments[i]
+	if segment.isIndex {
+		items, ok := v.([]any)
+		if !ok {
+			return nil, ErrMapPathType.Wrapf("path=%s value_type=%T desired_type=[]any", formatMapPath(segments[:i+1]), v)
+		}
+		if segment.index >= len(items) {
+			return nil, ErrMapPathNotFound.Wrapf("path=%s", formatMapPath(segments[:i+1]))
+		}
+		return items[segment.index], nil
+	}
+
+	m, ok := v.(map[string]any)
+	if !ok {
+		return nil, ErrMapPathType.Wrapf("path=%s value_type=%T desired_type=map[string]any", formatMapPath(segments[:i+1]), v)
+	}
+	child, ok := m[segment.key]
+	if !ok {
+		return nil, ErrMapPathNotFound.Wrapf("path=%s", formatMapPath(segments[:i+1]))
+	}
+	return child, nil
+}
+
+// mapPathSet sets the value at the segments from index i onwards within v and
+// returns v, which may have been created or grown.
+func mapPathSet(v any, segments []mapPathSegment, i int, value any) (any, error) {
+	if i == len(segments) {
+		return value, nil
+	}
+
+	segment := segments[i]
+	if segment.isIndex {
+		var items []any
+		switch c := v.(type) {
+		case nil:
+		case []any:
+			items = c
+		default:
+			return nil, ErrMapPathType.Wrapf("path=%s value_type=%T desired_type=[]any", formatMapPath(segments[:i+1]), v)
+		}
+		for len(items) <= segment.index {
+			items = append(items, nil)
+		}
+		child, err := mapPathSet(items[segment.index], segments, i+1, value)
+		if err != nil {
+			return nil, err
+		}
+		items[segment.index] = child
+		return items, nil
+	}
+
+	var m map[string]any
+	switch c := v.(type) {
+	case nil:
+		m = make(map[string]any)
+	case map[string]any:
+		// A typed nil map can't be written to, so it is replaced like an untyped nil.
+		m = c
+		if m == nil {
+			m = make(map[string]any)
+		}
+	default:
+		return nil, ErrMapPathType.Wrapf("path=%s value_type=%T desired_type=map[string]any", formatMapPath(segments[:i+1]), v)
+	}
+	child, err := mapPathSet(m[segment.key], segments, i+1, value)
+	if err != nil {
+		return nil, err
+	}
+	m[segment.key] = child
+	return m, nil
+}
+
+// mapPathSegment is a single map key or slice index in a path.
+type mapPathSegment struct {
+	key     string
+	index   int
+	isIndex bool
+}
+
+// parseMapPath parses a path expression such as 'a.b[2].c' into segments.
+func parseMapPath(path string) ([]mapPathSegment, error) {
+	invalid := func(reason string) error {
+		return ErrMapPathInvalid.Wrapf("path=%q reason=%s", path, reason)
+	}
+	if path == "" {
+		return nil, invalid("empty path")
+	}
+
+	var segments []mapPathSegment
+	for i := 0; i < len(path); {
+		switch path[i] {
+		case '[':
+			end := strings.IndexByte(path[i:], ']')
+			if end < 0 {
+				return nil, invalid("unterminated index")
+			}
+			index, err := strconv.Atoi(path[i+1 : i+end])
+			if err != nil || index < 0 {
+				return nil, invalid("index is not a non-negative integer")
+			}
+			segments = append(segments, mapPathSegment{index: index, isIndex: true})
+			i += end + 1
+		case '.':
+			if len(segments) == 0 || i+1 == len(path) || path[i+1] == '.' || path[i+1] == '[' {
+				return nil, invalid("empty key")
+			}
+			i++
+		default:
+			if len(segments) > 0 && path[i-1] != '.' {
+				return nil, invalid("missing separator before key")
+			}
+			end := strings.IndexAny(path[i:], ".[")
+			if end < 0 {
+				end = len(path) - i
+			}
+			segments = append(segments, mapPathSegment{key: path[i : i+end]})
+			i += end
+		}
+	}
+	return segments, nil
+}
+
+// formatMapPath returns the path expression for the segments.
+func formatMapPath(segments []mapPathSegment) string {
+	var path string
+	for _, segment := range segments {
+		if segment.isIndex {
+			path = mapPathIndex(path, segment.index)
+		} else {
+			path = mapPathKey(path, segment.key)
+		}
+	}
+	return path
+}
+
+// mapPathKey returns the path of the key within the prefix.
+func mapPathKey(prefix string, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
+
+// mapPathIndex returns the path of the index within the prefix.
+func mapPathIndex(prefix string, index int) string {
+	return fmt.Sprintf("%s[%d]", prefix, index)
+}
@@ -0,0 +1,146 @@
+// Code generated by go-enum DO NOT EDIT.
+// Version: 0.6.0
+// Revision: 919e61c0174b91303753ee3898569a01abb32c97
+// Build Date: 2023-12-18T15:54:43Z
+// Built By: goreleaser
+
+package stdlib
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// MapChangeTypeAdded is a MapChangeType of type added.
+	MapChangeTypeAdded MapChangeType = "added"
+	// MapChangeTypeRemoved is a MapChangeType of type removed.
+	MapChangeTypeRemoved MapChangeType = "removed"
+	// MapChangeTypeChanged is a MapChangeType of type changed.
+	MapChangeTypeChanged MapChangeType = "changed"
+)
+
+var ErrInvalidMapChangeType = fmt.Errorf("not a valid MapChangeType, try [%s]", strings.Join(_MapChangeTypeNames, ", "))
+
+var _MapChangeTypeNames = []string{
+	string(MapChangeTypeAdded),
+	string(MapChangeTypeRemoved),
+	string(MapChangeTypeChanged),
+}
+
+// MapChangeTypeNames returns a list of possible string values of MapChangeType.
+func MapChangeTypeNames() []string {
+	tmp := make([]string, len(_MapChangeTypeNames))
+	copy(tmp, _MapChangeTypeNames)
+	return tmp
+}
+
+// String implements the Stringer interface.
+func (x MapChangeType) String() string {
+	return string(x)
+}
+
+// IsValid provides a quick way to determine if the typed value is
+// part of the allowed enumerated values
+func (x MapChangeType) IsValid() bool {
+	_, err := ParseMapChangeType(string(x))
+	return err == nil
+}
+
+var _MapChangeTypeValue = map[string]MapChangeType{
+	"added":   MapChangeTypeAdded,
+	"removed": MapChangeTypeRemoved,
+	"changed": MapChangeTypeChanged,
+}
+
+// ParseMapChangeType attempts to convert a string to a MapChangeType.
+func ParseMapChangeType(name string) (MapChangeType, error) {
+	if x, ok := _MapChangeTypeValue[name]; ok {
+		return x, nil
+	}
+	return MapChangeType(""), fmt.Errorf("%s is %w", name, ErrInvalidMapChangeType)
+}
+
+// MarshalText implements the text marshaller method.
+func (x MapChangeType) MarshalText() ([]byte, error) {
+	return []byte(string(x)), nil
+}
+
+// UnmarshalText implements the text unmarshaller method.
+func (x *MapChangeType) UnmarshalText(text []byte) error {
+	tmp, err := ParseMapChangeType(string(text))
+	if err != nil {
+		return err
+	}
+	*x = tmp
+	return nil
+}
+
+const (
+	// MapMergeStrategyOverride is a MapMergeStrategy of type override.
+	MapMergeStrategyOverride MapMergeStrategy = "override"
+	// MapMergeStrategyKeep is a MapMergeStrategy of type keep.
+	MapMergeStrategyKeep MapMergeStrategy = "keep"
+	// MapMergeStrategyAppend is a MapMergeStrategy of type append.
+	MapMergeStrategyAppend MapMergeStrategy = "append"
+	// MapMergeStrategyError is a MapMergeStrategy of type error.
+	MapMergeStrategyError MapMergeStrategy = "error"
+)
+
+var ErrInvalidMapMergeStrategy = fmt.Errorf("not a valid MapMergeStrategy, try [%s]", strings.Join(_MapMergeStrategyNames, ", "))
+
+var _MapMergeStrategyNames = []string{
+	string(MapMergeStrategyOverride),
+	string(MapMergeStrategyKeep),
+	string(MapMergeStrategyAppend),
+	string(MapMergeStrategyError),
+}
+
+// MapMergeStrategyNames returns a list of possible string values of MapMergeStrategy.
+func MapMergeStrategyNames() []string {
+	tmp := make([]string, len(_MapMergeStrategyNames))
+	copy(tmp, _MapMergeStrategyNames)
+	return tmp
+}
+
+// String implements the Stringer interface.
+func (x MapMergeStrategy) String() string {
+	return string(x)
+}
+
+// IsValid provides a quick way to determine if the typed value is
+// part of the allowed enumerated values
+func (x MapMergeStrategy) IsValid() bool {
+	_, err := ParseMapMergeStrategy(string(x))
+	return err == nil
+}
+
+var _MapMergeStrategyValue = map[string]MapMergeStrategy{
+	"override": MapMergeStrategyOverride,
+	"keep":     MapMergeStrategyKeep,
+	"append":   MapMergeStrategyAppend,
+	"error":    MapMergeStrategyError,
+}
+
+// ParseMapMergeStrategy attempts to convert a string to a MapMergeStrategy.
+func ParseMapMergeStrategy(name string) (MapMergeStrategy, error) {
+	if x, ok := _MapMergeStrategyValue[name]; ok {
+		return x, nil
+	}
+	return MapMergeStrategy(""), fmt.Errorf("%s is %w", name, ErrInvalidMapMergeStrategy)
+}
+
+// MarshalText implements the text marshaller method.
+func (x MapMergeStrategy) MarshalText() ([]byte, error) {
+	return []byte(string(x)), nil
+}
+
+// UnmarshalText implements the text unmarshaller method.
+func (x *MapMergeStrategy) UnmarshalText(text []byte) error {
+	tmp, err := ParseMapMergeStrategy(string(text))
+	if err != nil {
+		return err
+	}
+	*x = tmp
+	return nil
+}
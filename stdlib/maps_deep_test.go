@@ -0,0 +1,96 @@
+package stdlib_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ahawker/stdlibx-go/stdlib"
+	"github.com/ahawker/stdlibx-go/stdtest"
+)
+
+func TestMapMerge(t *testing.T) {
+	test := stdtest.NewTest(t)
+
+	base := map[string]any{"a": map[string]any{"x": 1, "tags": []any{"a"}}, "b": 1}
+	layer := map[string]any{"a": map[string]any{"y": 2, "tags": []any{"b"}}, "b": 2}
+
+	merged, err := stdlib.MapMerge(stdlib.MapMergeStrategyOverride, base, layer)
+	test.OK(err)
+	test.Equal(merged, map[string]any{"a": map[string]any{"x": 1, "y": 2, "tags": []any{"b"}}, "b": 2})
+
+	merged, err = stdlib.MapMerge(stdlib.MapMergeStrategyKeep, base, layer)
+	test.OK(err)
+	test.Equal(merged, map[string]any{"a": map[string]any{"x": 1, "y": 2, "tags": []any{"a"}}, "b": 1})
+
+	merged, err = stdlib.MapMerge(stdlib.MapMergeStrategyAppend, base, layer)
+	test.OK(err)
+	test.Equal(merged, map[string]any{"a": map[string]any{"x": 1, "y": 2, "tags": []any{"a", "b"}}, "b": 2})
+	test.Equal(base["a"].(map[string]any)["tags"], []any{"a"})
+
+	_, err = stdlib.MapMerge(stdlib.MapMergeStrategyError, base, layer)
+	test.True(errors.Is(err, stdlib.ErrMapMergeConflict), "want ErrMapMergeConflict got %v", err)
+}
+
+func TestMapDiff(t *testing.T) {
+	test := stdtest.NewTest(t)
+
+	a := map[string]any{"a": map[string]any{"x": 1, "y": 2}, "b": 1}
+	b := map[string]any{"a": map[string]any{"x": 1, "y": 3}, "c": true}
+	test.Equal(stdlib.MapDiff(a, b), []stdlib.MapChange{
+		{Path: "a.y", Type: stdlib.MapChangeTypeChanged, Old: 2, New: 3},
+		{Path: "b", Type: stdlib.MapChangeTypeRemoved, Old: 1},
+		{Path: "c", Type: stdlib.MapChangeTypeAdded, New: true},
+	})
+}
+
+func TestMapFlatten(t *testing.T) {
+	test := stdtest.NewTest(t)
+
+	nested := map[string]any{
+		"a":     map[string]any{"b": []any{1, map[string]any{"c": "d"}}},
+		"empty": map[string]any{},
+	}
+	flat := stdlib.MapFlatten(nested)
+	test.Equal(flat, map[string]any{"a.b[0]": 1, "a.b[1].c": "d", "empty": map[string]any{}})
+
+	unflat, err := stdlib.MapUnflatten(flat)
+	test.OK(err)
+	test.Equal(unflat, nested)
+}
+
+func TestMapPath(t *testing.T) {
+	test := stdtest.NewTest(t)
+
+	m := map[string]any{"a": map[string]any{"b": []any{0, 1, map[string]any{"c": "d"}}}}
+
+	v, err := stdlib.MapPathGet(m, "a.b[2].c")
+	test.OK(err)
+	test.Equal(v, any("d"))
+
+	_, err = stdlib.MapPathGet(m, "a.b[5]")
+	test.True(errors.Is(err, stdlib.ErrMapPathNotFound), "want ErrMapPathNotFound got %v", err)
+	_, err = stdlib.MapPathGet(m, "a.b.c")
+	test.True(errors.Is(err, stdlib.ErrMapPathType), "want ErrMapPathType got %v", err)
+	for _, path := range []string{"", "a..b", "a.", "a[x]", "a[1", "a[0]b"} {
+		_, err = stdlib.MapPathGet(m, path)
+		test.True(errors.Is(err, stdlib.ErrMapPathInvalid), "want ErrMapPathInvalid for %q got %v", path, err)
+	}
+
+	test.OK(stdlib.MapPathSet(m, "a.b[2].e", 1))
+	test.OK(stdlib.MapPathSet(m, "x.y[1]", true))
+	test.Equal(m["x"], any(map[string]any{"y": []any{nil, true}}))
+
+	// Typed nil maps are replaced rather than written to.
+	m["n"] = map[string]any(nil)
+	test.OK(stdlib.MapPathSet(m, "n.k", 1))
+	test.Equal(m["n"], any(map[string]any{"k": 1}))
+	delete(m, "n")
+	err = stdlib.MapPathSet(nil, "a", 1)
+	test.True(errors.Is(err, stdlib.ErrMapPathType), "want ErrMapPathType got %v", err)
+
+	test.OK(stdlib.MapPathDelete(m, "a.b[0]"))
+	test.OK(stdlib.MapPathDelete(m, "a.b[1].c"))
+	test.Equal(m["a"], any(map[string]any{"b": []any{1, map[string]any{"e": 1}}}))
+	err = stdlib.MapPathDelete(m, "a.missing")
+	test.True(errors.Is(err, stdlib.ErrMapPathNotFound), "want ErrMapPathNotFound got %v", err)
+}
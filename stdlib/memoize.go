@@ -1,24 +1,246 @@
 package stdlib
 
-import "sync"
+import (
+	"context"
+	"sync"
+	"time"
+)
 
-// Memoize is a simple struct that wraps a `sync.Once`
-// to provide thread safe memoized results for costly computation.
+// ErrMemoizePanic is returned when the memoized function panics.
+var ErrMemoizePanic = Error{
+	Code:      "memoize_panic",
+	Message:   "memoized function panicked",
+	Namespace: ErrorNamespaceDefault,
+}
+
+// MemoizeConfig for a memoized computation.
+type MemoizeConfig struct {
+	// TTL is how long a successful result is cached. Zero caches forever.
+	TTL time.Duration
+	// ErrorTTL is how long a failed result is cached. Zero uses the TTL and
+	// a negative value disables caching of errors.
+	ErrorTTL time.Duration
+	// RefreshAhead starts a background recomputation when a cached result
+	// is within this duration of expiring. Zero disables refresh-ahead.
+	RefreshAhead time.Duration
+	// Clock is the source of time for expiry.
+	Clock Clock
+}
+
+// WithMemoizeTTL sets how long a successful result is cached.
+func WithMemoizeTTL(ttl time.Duration) Option[*MemoizeConfig] {
+	return func(o *MemoizeConfig) error {
+		o.TTL = ttl
+		return nil
+	}
+}
+
+// WithMemoizeErrorTTL sets how long a failed result is cached. A negative
+// value disables caching of errors.
+func WithMemoizeErrorTTL(ttl time.Duration) Option[*MemoizeConfig] {
+	return func(o *MemoizeConfig) error {
+		o.ErrorTTL = ttl
+		return nil
+	}
+}
+
+// WithMemoizeRefreshAhead sets how long before expiry a background refresh starts.
+func WithMemoizeRefreshAhead(d time.Duration) Option[*MemoizeConfig] {
+	return func(o *MemoizeConfig) error {
+		o.RefreshAhead = d
+		return nil
+	}
+}
+
+// WithMemoizeClock sets the clock used for expiry.
+func WithMemoizeClock(clock Clock) Option[*MemoizeConfig] {
+	return func(o *MemoizeConfig) error {
+		o.Clock = clock
+		return nil
+	}
+}
+
+// defaultMemoizeConfig caches results and errors forever.
+var defaultMemoizeConfig = &MemoizeConfig{Clock: DefaultClock}
+
+// NewMemoize creates a new *Memoize for the context-aware function and options.
+func NewMemoize[T any](fn func(ctx context.Context) (T, error), options ...Option[*MemoizeConfig]) (*Memoize[T], error) {
+	cfg, err := OptionApply(&MemoizeConfig{Clock: DefaultClock}, options...)
+	if err != nil {
+		return nil, err
+	}
+	return &Memoize[T]{FnCtx: fn, config: cfg}, nil
+}
+
+// Memoize provides thread safe memoized results for costly computation.
+//
+// The zero value with 'Fn' set computes once and caches the result/error forever.
+// Use 'NewMemoize' for expiry, error caching and refresh-ahead options.
 type Memoize[T any] struct {
-	// Fn is called once and its result/error is cached.
+	// Fn is called to compute the result/error to cache.
 	Fn func() (T, error)
-	// res stores result of the computation.
-	res T
-	// err stores error if the computation failed.
-	err error
-	// once is used to ensure computation is only performed one time.
-	once sync.Once
+	// FnCtx is called to compute the result/error to cache and takes
+	// precedence over Fn when set.
+	FnCtx func(ctx context.Context) (T, error)
+	// config for expiry and error caching.
+	config *MemoizeConfig
+	// mu guards all fields below.
+	mu sync.Mutex
+	// entry is the cached result, if any.
+	entry *memoizeEntry[T]
+	// call is the in-flight computation, if any.
+	call *memoizeCall[T]
+	// generation is incremented on Reset so in-flight results are discarded.
+	generation uint64
 }
 
-// Get returns the value + error from the
+// Get returns the value + error from the computation, computing it if necessary.
 func (m *Memoize[T]) Get() (T, error) {
-	m.once.Do(func() {
-		m.res, m.err = m.Fn()
-	})
-	return m.res, m.err
+	return m.GetCtx(context.Background())
+}
+
+// GetCtx returns the value + error from the computation, computing it if necessary.
+//
+// Concurrent callers share one in-flight computation. Each caller stops waiting
+// when its context is done, but the computation continues for the others.
+func (m *Memoize[T]) GetCtx(ctx context.Context) (T, error) {
+	cfg := m.cfg()
+
+	m.mu.Lock()
+	now := cfg.Clock.Now()
+	if e := m.entry; e != nil && e.valid(now) {
+		if m.call == nil && e.refresh(now, cfg.RefreshAhead) {
+			m.start(ctx, cfg)
+		}
+		m.mu.Unlock()
+		return e.value, e.err
+	}
+
+	call := m.call
+	if call == nil {
+		call = m.start(ctx, cfg)
+	}
+	m.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.value, call.err
+	case <-ctx.Done():
+		return *new(T), context.Cause(ctx)
+	}
+}
+
+// Reset discards the cached result so the next call recomputes it.
+func (m *Memoize[T]) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.entry = nil
+	m.call = nil
+	m.generation++
+}
+
+// cfg returns the config or defaults when created as a struct literal.
+func (m *Memoize[T]) cfg() *MemoizeConfig {
+	if m.config == nil {
+		return defaultMemoizeConfig
+	}
+	return m.config
+}
+
+// start begins computation in the background and returns the in-flight call.
+//
+// The computation is detached from the cancellation of ctx, since other callers
+// may be waiting on it. Callers must hold the lock.
+func (m *Memoize[T]) start(ctx context.Context, cfg *MemoizeConfig) *memoizeCall[T] {
+	call := &memoizeCall[T]{done: make(chan struct{})}
+	m.call = call
+	generation := m.generation
+
+	go func() {
+		defer close(call.done)
+		call.value, call.err = m.compute(context.WithoutCancel(ctx))
+
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		if m.generation != generation {
+			return
+		}
+		m.call = nil
+		if entry := m.store(call, cfg); entry != nil {
+			m.entry = entry
+		}
+	}()
+	return call
+}
+
+// compute calls the configured function.
+//
+// A panic is recovered and returned as ErrMemoizePanic, since it happens on a
+// detached goroutine and would otherwise crash the process.
+func (m *Memoize[T]) compute(ctx context.Context) (value T, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			value, err = *new(T), ErrMemoizePanic.Wrapf("panic=%v", r)
+		}
+	}()
+
+	if m.FnCtx != nil {
+		return m.FnCtx(ctx)
+	}
+	return m.Fn()
+}
+
+// store returns the entry to cache for the completed call, or nil if it
+// should not replace the current entry. Callers must hold the lock.
+func (m *Memoize[T]) store(call *memoizeCall[T], cfg *MemoizeConfig) *memoizeEntry[T] {
+	now := cfg.Clock.Now()
+	entry := &memoizeEntry[T]{value: call.value, err: call.err}
+
+	ttl := cfg.TTL
+	if call.err != nil {
+		if cfg.ErrorTTL < 0 {
+			return nil
+		}
+		// Failed refreshes shouldn't replace a result that is still valid.
+		if m.entry != nil && m.entry.err == nil && m.entry.valid(now) {
+			return nil
+		}
+		if cfg.ErrorTTL > 0 {
+			ttl = cfg.ErrorTTL
+		}
+	}
+	if ttl > 0 {
+		entry.expires = now.Add(ttl)
+	}
+	return entry
+}
+
+// memoizeEntry is a cached result.
+type memoizeEntry[T any] struct {
+	value T
+	err   error
+	// expires is when the entry is no longer valid. Zero never expires.
+	expires time.Time
+}
+
+// valid returns true if the entry has not expired.
+func (e *memoizeEntry[T]) valid(now time.Time) bool {
+	return e.expires.IsZero() || now.Before(e.expires)
+}
+
+// refresh returns true if a successful entry is close enough to expiry to be refreshed.
+func (e *memoizeEntry[T]) refresh(now time.Time, ahead time.Duration) bool {
+	if ahead <= 0 || e.err != nil || e.expires.IsZero() {
+		return false
+	}
+	return !now.Before(e.expires.Add(-ahead))
+}
+
+// memoizeCall is an in-flight computation.
+type memoizeCall[T any] struct {
+	value T
+	err   error
+	done  chan struct{}
 }
@@ -0,0 +1,357 @@
+package stdlib
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var _ KeyedRanger[string, any] = (*MemoizeMap[string, any])(nil)
+
+// MemoizeMapConfig for a keyed memoized computation.
+type MemoizeMapConfig[K comparable, V any] struct {
+	// MaxSize is the max number of cached entries. Zero or less is unbounded.
+	MaxSize int
+	// Eviction is the policy used to choose entries to evict when full.
+	Eviction EvictionPolicy
+	// TTL is how long a successful result is cached. Zero caches forever.
+	TTL time.Duration
+	// ErrorTTL is how long a failed result is cached. Zero uses the TTL and
+	// a negative value disables caching of errors.
+	ErrorTTL time.Duration
+	// OnEvict is called when an entry is evicted, expires or is deleted.
+	OnEvict func(k K, v V)
+	// Clock is the source of time for expiry.
+	Clock Clock
+}
+
+// WithMemoizeMapMaxSize sets the max number of cached entries.
+func WithMemoizeMapMaxSize[K comparable, V any](size int) Option[*MemoizeMapConfig[K, V]] {
+	return func(o *MemoizeMapConfig[K, V]) error {
+		o.MaxSize = size
+		return nil
+	}
+}
+
+// WithMemoizeMapEviction sets the policy used to choose entries to evict.
+func WithMemoizeMapEviction[K comparable, V any](policy EvictionPolicy) Option[*MemoizeMapConfig[K, V]] {
+	return func(o *MemoizeMapConfig[K, V]) error {
+		if !policy.IsValid() {
+			return ErrInvalidEvictionPolicy
+		}
+		o.Eviction = policy
+		return nil
+	}
+}
+
+// WithMemoizeMapTTL sets how long a successful result is cached.
+func WithMemoizeMapTTL[K comparable, V any](ttl time.Duration) Option[*MemoizeMapConfig[K, V]] {
+	return func(o *MemoizeMapConfig[K, V]) error {
+		o.TTL = ttl
+		return nil
+	}
+}
+
+// WithMemoizeMapErrorTTL sets how long a failed result is cached. A negative
+// value disables caching of errors.
+func WithMemoizeMapErrorTTL[K comparable, V any](ttl time.Duration) Option[*MemoizeMapConfig[K, V]] {
+	return func(o *MemoizeMapConfig[K, V]) error {
+		o.ErrorTTL = ttl
+		return nil
+	}
+}
+
+// WithMemoizeMapOnEvict sets the callback for evicted entries, e.g. 'EvictCloser'.
+func WithMemoizeMapOnEvict[K comparable, V any](fn func(k K, v V)) Option[*MemoizeMapConfig[K, V]] {
+	return func(o *MemoizeMapConfig[K, V]) error {
+		o.OnEvict = fn
+		return nil
+	}
+}
+
+// WithMemoizeMapClock sets the clock used for expiry.
+func WithMemoizeMapClock[K comparable, V any](clock Clock) Option[*MemoizeMapConfig[K, V]] {
+	return func(o *MemoizeMapConfig[K, V]) error {
+		o.Clock = clock
+		return nil
+	}
+}
+
+// MemoizeMapStats are counters describing cache effectiveness.
+type MemoizeMapStats struct {
+	// Hits is the number of lookups served from the cache.
+	Hits uint64
+	// Misses is the number of lookups that required computation.
+	Misses uint64
+	// Evictions is the number of entries removed by the eviction policy or expiry.
+	Evictions uint64
+	// Size is the current number of cached entries.
+	Size int
+}
+
+// NewMemoizeMap creates a new *MemoizeMap for the context-aware function and options.
+func NewMemoizeMap[K comparable, V any](
+	fn func(ctx context.Context, k K) (V, error),
+	options ...Option[*MemoizeMapConfig[K, V]],
+) (*MemoizeMap[K, V], error) {
+	cfg, err := OptionApply(&MemoizeMapConfig[K, V]{
+		Eviction: EvictionPolicyLru,
+		Clock:    DefaultClock,
+	}, options...)
+	if err != nil {
+		return nil, err
+	}
+	return &MemoizeMap[K, V]{
+		fn:      fn,
+		config:  cfg,
+		entries: make(map[K]*memoizeEntry[V]),
+		calls:   make(map[K]*memoizeCall[V]),
+		evictor: newEvictor[K](cfg.Eviction, cfg.MaxSize),
+	}, nil
+}
+
+// MemoizeMap provides thread safe memoized results for costly computation by key.
+//
+// Concurrent lookups for the same key share one in-flight computation. The
+// number of entries can be bounded with an eviction policy.
+type MemoizeMap[K comparable, V any] struct {
+	// fn is called to compute the value for a key.
+	fn func(ctx context.Context, k K) (V, error)
+	// config for size, expiry and eviction.
+	config *MemoizeMapConfig[K, V]
+	// mu guards entries, calls and evictor.
+	mu sync.Mutex
+	// entries are the cached results.
+	entries map[K]*memoizeEntry[V]
+	// calls are the in-flight computations.
+	calls map[K]*memoizeCall[V]
+	// evictor tracks usage to choose entries to evict.
+	evictor evictor[K]
+	// hits, misses and evictions are stat counters.
+	hits, misses, evictions atomic.Uint64
+}
+
+// Get returns the value + error for the key, computing it if necessary.
+func (m *MemoizeMap[K, V]) Get(k K) (V, error) {
+	return m.GetCtx(context.Background(), k)
+}
+
+// GetCtx returns the value + error for the key, computing it if necessary.
+//
+// Concurrent callers for the same key share one in-flight computation. Each
+// caller stops waiting when its context is done, but the computation continues
+// for the others.
+func (m *MemoizeMap[K, V]) GetCtx(ctx context.Context, k K) (V, error) {
+	var evicted []memoizeMapEvicted[K, V]
+	defer func() { m.evict(evicted) }()
+
+	m.mu.Lock()
+	now := m.config.Clock.Now()
+	if e, ok := m.entries[k]; ok {
+		if e.valid(now) {
+			m.evictor.access(k)
+			m.mu.Unlock()
+			m.hits.Add(1)
+			return e.value, e.err
+		}
+		evicted = append(evicted, m.unlink(k, e))
+		m.evictions.Add(1)
+	}
+	m.misses.Add(1)
+
+	call, ok := m.calls[k]
+	if !ok {
+		call = m.start(ctx, k)
+	}
+	m.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.value, call.err
+	case <-ctx.Done():
+		return *new(V), context.Cause(ctx)
+	}
+}
+
+// Delete removes the cached entry for the key.
+func (m *MemoizeMap[K, V]) Delete(k K) {
+	var evicted []memoizeMapEvicted[K, V]
+	defer func() { m.evict(evicted) }()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if e, ok := m.entries[k]; ok {
+		evicted = append(evicted, m.unlink(k, e))
+	}
+	delete(m.calls, k)
+}
+
+// Reset removes all cached entries.
+func (m *MemoizeMap[K, V]) Reset() {
+	var evicted []memoizeMapEvicted[K, V]
+	defer func() { m.evict(evicted) }()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for k, e := range m.entries {
+		evicted = append(evicted, m.unlink(k, e))
+	}
+	m.calls = make(map[K]*memoizeCall[V])
+	m.evictor = newEvictor[K](m.config.Eviction, m.config.MaxSize)
+}
+
+// Len returns the number of cached entries, including any that have expired
+// but not yet been removed.
+func (m *MemoizeMap[K, V]) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.entries)
+}
+
+// Stats returns the cache counters.
+func (m *MemoizeMap[K, V]) Stats() MemoizeMapStats {
+	return MemoizeMapStats{
+		Hits:      m.hits.Load(),
+		Misses:    m.misses.Load(),
+		Evictions: m.evictions.Load(),
+		Size:      m.Len(),
+	}
+}
+
+// Range calls the predicate for all successfully cached, unexpired entries.
+//
+// If the predicate returns `false`, iteration will stop.
+//
+// Interface: KeyedRanger.
+func (m *MemoizeMap[K, V]) Range(predicate KeyedPredicate[K, V]) {
+	m.mu.Lock()
+	now := m.config.Clock.Now()
+	snapshot := make(map[K]V, len(m.entries))
+	for k, e := range m.entries {
+		if e.err == nil && e.valid(now) {
+			snapshot[k] = e.value
+		}
+	}
+	m.mu.Unlock()
+
+	for k, v := range snapshot {
+		if !predicate(k, v) {
+			return
+		}
+	}
+}
+
+// Keys returns a Ranger over the keys of all successfully cached, unexpired entries.
+func (m *MemoizeMap[K, V]) Keys() Ranger[K] {
+	return rangerFn[K](func(predicate Predicate[K]) {
+		m.Range(func(k K, _ V) bool {
+			return predicate(k)
+		})
+	})
+}
+
+// start begins computation for the key in the background and returns the in-flight call.
+//
+// Callers must hold the lock.
+func (m *MemoizeMap[K, V]) start(ctx context.Context, k K) *memoizeCall[V] {
+	call := &memoizeCall[V]{done: make(chan struct{})}
+	m.calls[k] = call
+
+	go func() {
+		defer close(call.done)
+		call.value, call.err = m.compute(context.WithoutCancel(ctx), k)
+
+		var evicted []memoizeMapEvicted[K, V]
+		defer func() { m.evict(evicted) }()
+
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		// The key was deleted or the map reset while computing.
+		if m.calls[k] != call {
+			return
+		}
+		delete(m.calls, k)
+		evicted = m.store(k, call)
+	}()
+	return call
+}
+
+// compute calls the function for the key and recovers a panic as ErrMemoizePanic.
+func (m *MemoizeMap[K, V]) compute(ctx context.Context, k K) (value V, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			value, err = *new(V), ErrMemoizePanic.Wrapf("key=%v panic=%v", k, r)
+		}
+	}()
+	return m.fn(ctx, k)
+}
+
+// store caches the result of the call and returns any entries evicted to make room.
+//
+// Callers must hold the lock.
+func (m *MemoizeMap[K, V]) store(k K, call *memoizeCall[V]) []memoizeMapEvicted[K, V] {
+	ttl := m.config.TTL
+	if call.err != nil {
+		if m.config.ErrorTTL < 0 {
+			return nil
+		}
+		if m.config.ErrorTTL > 0 {
+			ttl = m.config.ErrorTTL
+		}
+	}
+	entry := &memoizeEntry[V]{value: call.value, err: call.err}
+	if ttl > 0 {
+		entry.expires = m.config.Clock.Now().Add(ttl)
+	}
+
+	var evicted []memoizeMapEvicted[K, V]
+	if m.config.MaxSize > 0 {
+		for len(m.entries) >= m.config.MaxSize {
+			victim, ok := m.evictor.victim(k)
+			if !ok {
+				break
+			}
+			if e, ok := m.entries[victim]; ok {
+				delete(m.entries, victim)
+				evicted = append(evicted, memoizeMapEvicted[K, V]{key: victim, entry: e})
+				m.evictions.Add(1)
+			}
+		}
+	}
+	m.entries[k] = entry
+	m.evictor.add(k)
+	return evicted
+}
+
+// unlink removes the entry for the key and returns it for eviction callbacks.
+//
+// Callers must hold the lock.
+func (m *MemoizeMap[K, V]) unlink(k K, e *memoizeEntry[V]) memoizeMapEvicted[K, V] {
+	delete(m.entries, k)
+	m.evictor.remove(k)
+	return memoizeMapEvicted[K, V]{key: k, entry: e}
+}
+
+// evict calls the eviction callback for successfully computed entries.
+//
+// Called without the lock held, since callbacks may be slow, e.g. closing resources.
+func (m *MemoizeMap[K, V]) evict(evicted []memoizeMapEvicted[K, V]) {
+	if m.config.OnEvict == nil {
+		return
+	}
+	for _, e := range evicted {
+		if e.entry.err == nil {
+			m.config.OnEvict(e.key, e.entry.value)
+		}
+	}
+}
+
+// memoizeMapEvicted is an entry removed from a MemoizeMap.
+type memoizeMapEvicted[K comparable, V any] struct {
+	key   K
+	entry *memoizeEntry[V]
+}
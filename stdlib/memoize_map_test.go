@@ -0,0 +1,145 @@
+package stdlib_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ahawker/stdlibx-go/stdlib"
+	"github.com/ahawker/stdlibx-go/stdtest"
+)
+
+type closeCounter struct{ closed *atomic.Int32 }
+
+func (c closeCounter) Close() error {
+	c.closed.Add(1)
+	return nil
+}
+
+func TestMemoizeMapEviction(t *testing.T) {
+	type Got struct {
+		policy  stdlib.EvictionPolicy
+		lookups []int
+	}
+	stdtest.Table[Got, []int]{
+		"pass: lru evicts least recently used": {
+			Got: Got{
+				policy:  stdlib.EvictionPolicyLru,
+				lookups: []int{1, 2, 1, 3},
+			},
+			Want: []int{1, 3},
+		},
+		"pass: lfu evicts least frequently used": {
+			Got: Got{
+				policy:  stdlib.EvictionPolicyLfu,
+				lookups: []int{1, 1, 2, 2, 2, 3},
+			},
+			Want: []int{2, 3},
+		},
+		"pass: arc keeps frequently used over a scan": {
+			Got: Got{
+				policy:  stdlib.EvictionPolicyArc,
+				lookups: []int{1, 1, 2, 3, 4},
+			},
+			Want: []int{1, 4},
+		},
+	}.Run(t, func(t *stdtest.Test, tc stdtest.Testcase[Got, []int]) {
+		m, err := stdlib.NewMemoizeMap(func(ctx context.Context, k int) (int, error) {
+			return k * 10, nil
+		},
+			stdlib.WithMemoizeMapMaxSize[int, int](2),
+			stdlib.WithMemoizeMapEviction[int, int](tc.Got.policy),
+		)
+		t.OK(err)
+		for _, k := range tc.Got.lookups {
+			v, err := m.Get(k)
+			t.OK(err)
+			t.Equal(v, k*10)
+		}
+		for _, k := range tc.Want {
+			t.True(len(stdlib.MapFilterRange[int, int](m, func(key int, _ int) bool { return key == k })) == 1,
+				"want key %d cached", k)
+		}
+		t.Equal(m.Len(), 2)
+	})
+}
+
+func TestMemoizeMapSingleflight(t *testing.T) {
+	test := stdtest.NewTest(t)
+
+	var calls atomic.Int32
+	release := make(chan struct{})
+	m, err := stdlib.NewMemoizeMap(func(ctx context.Context, k string) (string, error) {
+		calls.Add(1)
+		<-release
+		return k, nil
+	})
+	test.OK(err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, err := m.Get("key")
+			test.OK(err)
+			test.Equal(v, "key")
+		}()
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	test.Equal(calls.Load(), int32(1))
+	_, _ = m.Get("key")
+	stats := m.Stats()
+	test.Equal(stats.Misses+stats.Hits, uint64(9))
+	test.True(stats.Hits >= 1, "want at least one hit")
+}
+
+func TestMemoizeMapExpiry(t *testing.T) {
+	clock := stdlib.NewFakeClock(epoch)
+	test := stdtest.NewTest(t, stdtest.WithTestClock(clock))
+
+	var closed atomic.Int32
+	m, err := stdlib.NewMemoizeMap(func(ctx context.Context, k string) (closeCounter, error) {
+		return closeCounter{closed: &closed}, nil
+	},
+		stdlib.WithMemoizeMapTTL[string, closeCounter](time.Minute),
+		stdlib.WithMemoizeMapOnEvict(stdlib.EvictCloser[string, closeCounter]),
+		stdlib.WithMemoizeMapClock[string, closeCounter](clock),
+	)
+	test.OK(err)
+
+	_, err = m.Get("a")
+	test.OK(err)
+	clock.Advance(2 * time.Minute)
+	_, err = m.Get("a")
+	test.OK(err)
+	test.Equal(closed.Load(), int32(1))
+	test.Equal(m.Stats().Evictions, uint64(1))
+
+	m.Reset()
+	test.Equal(closed.Load(), int32(2))
+}
+
+func TestMemoizeMapPanic(t *testing.T) {
+	test := stdtest.NewTest(t)
+
+	m, err := stdlib.NewMemoizeMap(func(ctx context.Context, k string) (int, error) {
+		if k == "panic" {
+			panic("boom")
+		}
+		return len(k), nil
+	})
+	test.OK(err)
+
+	_, err = m.Get("panic")
+	test.EqualError(err, stdlib.ErrMemoizePanic)
+
+	v, err := m.Get("ok")
+	test.OK(err)
+	test.Equal(v, 2)
+}
@@ -0,0 +1,107 @@
+package stdlib_test
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ahawker/stdlibx-go/stdlib"
+	"github.com/ahawker/stdlibx-go/stdtest"
+)
+
+func TestMemoizeGet(t *testing.T) {
+	test := stdtest.NewTest(t)
+
+	var calls atomic.Int32
+	m := &stdlib.Memoize[int]{Fn: func() (int, error) {
+		return int(calls.Add(1)), fmt.Errorf("failed")
+	}}
+	for i := 0; i < 3; i++ {
+		v, err := m.Get()
+		test.NotOK(err)
+		test.Equal(v, 1)
+	}
+
+	m.Reset()
+	v, _ := m.Get()
+	test.Equal(v, 2)
+}
+
+func TestMemoizeTTL(t *testing.T) {
+	clock := stdlib.NewFakeClock(epoch)
+	test := stdtest.NewTest(t, stdtest.WithTestClock(clock))
+
+	var calls atomic.Int32
+	m, err := stdlib.NewMemoize(func(ctx context.Context) (int, error) {
+		n := int(calls.Add(1))
+		if n == 2 {
+			return 0, fmt.Errorf("transient")
+		}
+		return n, nil
+	}, stdlib.WithMemoizeTTL(time.Minute), stdlib.WithMemoizeErrorTTL(-1), stdlib.WithMemoizeClock(clock))
+	test.OK(err)
+
+	v, err := m.Get()
+	test.OK(err)
+	test.Equal(v, 1)
+
+	clock.Advance(30 * time.Second)
+	v, _ = m.Get()
+	test.Equal(v, 1)
+
+	// Expired; the error is returned but not cached.
+	clock.Advance(time.Minute)
+	_, err = m.Get()
+	test.NotOK(err)
+
+	v, err = m.Get()
+	test.OK(err)
+	test.Equal(v, 3)
+}
+
+func TestMemoizeGetCtx(t *testing.T) {
+	test := stdtest.NewTest(t)
+
+	release := make(chan struct{})
+	m, err := stdlib.NewMemoize(func(ctx context.Context) (string, error) {
+		<-release
+		return "value", nil
+	})
+	test.OK(err)
+
+	ctx, cancel := context.WithCancel(test.Config.Context)
+	cancel()
+	_, err = m.GetCtx(ctx)
+	test.EqualError(err, context.Canceled)
+
+	close(release)
+	v, err := m.GetCtx(test.Config.Context)
+	test.OK(err)
+	test.Equal(v, "value")
+}
+
+func TestMemoizePanic(t *testing.T) {
+	test := stdtest.NewTest(t)
+
+	var calls atomic.Int32
+	m := &stdlib.Memoize[int]{Fn: func() (int, error) {
+		if calls.Add(1) == 1 {
+			panic("boom")
+		}
+		return 1, nil
+	}}
+	v, err := m.Get()
+	test.EqualError(err, stdlib.ErrMemoizePanic)
+	test.Equal(v, 0)
+
+	// The panic is cached like any other error until reset.
+	_, err = m.Get()
+	test.EqualError(err, stdlib.ErrMemoizePanic)
+
+	m.Reset()
+	v, err = m.Get()
+	test.OK(err)
+	test.Equal(v, 1)
+}
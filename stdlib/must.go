@@ -2,6 +2,7 @@ package stdlib
 
 import (
 	"fmt"
+	"golang.org/x/exp/constraints"
 	"reflect"
 	"time"
 )
@@ -33,6 +34,15 @@ func MustE[T any](fn func() (T, error)) T {
 	return Must[T](t)
 }
 
+// MustConvert returns the value converted to the type and panics if it cannot.
+func MustConvert[To any](value any) To {
+	v, err := Convert[To](value)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
 // MustMapAny returns the map[string]any of the given value and panics if it cannot.
 func MustMapAny[T any](value T) map[string]any {
 	v, err := ToMapAny[T](value)
@@ -78,6 +88,15 @@ func MustInt[T any](value T) int {
 	return v
 }
 
+// MustNumber returns the numeric representation of the given value and panics if it cannot.
+func MustNumber[T constraints.Integer | constraints.Float](value any) T {
+	v, err := ToNumber[T](value)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
 // MustDuration returns the time.Duration representation of the given value and panics if it cannot.
 func MustDuration[T any](value T) time.Duration {
 	v, err := ToDuration[T](value)
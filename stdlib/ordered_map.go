@@ -0,0 +1,269 @@
+package stdlib
+
+import (
+	"bytes"
+	"container/list"
+	"encoding/json"
+	"iter"
+)
+
+var (
+	_ KeyedRanger[string, any] = (*OrderedMap[string, any])(nil)
+	_ json.Marshaler           = (*OrderedMap[string, any])(nil)
+	_ json.Unmarshaler         = (*OrderedMap[string, any])(nil)
+)
+
+// ErrOrderedMapInvalidJSON is returned when decoding an OrderedMap from JSON that is not an object.
+var ErrOrderedMapInvalidJSON = Error{
+	Code:      "ordered_map_invalid_json",
+	Message:   "ordered map expected a JSON object",
+	Namespace: ErrorNamespaceDefault,
+}
+
+// NewOrderedMap creates a new, empty *OrderedMap.
+func NewOrderedMap[K comparable, V any]() *OrderedMap[K, V] {
+	return &OrderedMap[K, V]{
+		entries: make(map[K]*list.Element),
+		order:   list.New(),
+	}
+}
+
+// OrderedMap is a map that iterates in insertion order.
+//
+// Setting an existing key updates its value in place. It is not safe for concurrent use.
+type OrderedMap[K comparable, V any] struct {
+	// entries index the order elements by key.
+	entries map[K]*list.Element
+	// order of the entries, front to back.
+	order *list.List
+}
+
+// Get returns the value for the key and reports whether it was present.
+func (m *OrderedMap[K, V]) Get(k K) (V, bool) {
+	if e, ok := m.entries[k]; ok {
+		return e.Value.(*orderedMapEntry[K, V]).value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Set sets the value for the key, adding it to the back if not present.
+func (m *OrderedMap[K, V]) Set(k K, v V) {
+	m.init()
+	if e, ok := m.entries[k]; ok {
+		e.Value.(*orderedMapEntry[K, V]).value = v
+		return
+	}
+	m.entries[k] = m.order.PushBack(&orderedMapEntry[K, V]{key: k, value: v})
+}
+
+// Delete removes the key and reports whether it was present.
+func (m *OrderedMap[K, V]) Delete(k K) bool {
+	e, ok := m.entries[k]
+	if !ok {
+		return false
+	}
+	delete(m.entries, k)
+	m.order.Remove(e)
+	return true
+}
+
+// Has returns true if the key is present.
+func (m *OrderedMap[K, V]) Has(k K) bool {
+	_, ok := m.entries[k]
+	return ok
+}
+
+// Len returns the number of entries.
+func (m *OrderedMap[K, V]) Len() int {
+	return len(m.entries)
+}
+
+// MoveToFront moves the key to the front and reports whether it was present.
+func (m *OrderedMap[K, V]) MoveToFront(k K) bool {
+	e, ok := m.entries[k]
+	if ok {
+		m.order.MoveToFront(e)
+	}
+	return ok
+}
+
+// MoveToBack moves the key to the back and reports whether it was present.
+func (m *OrderedMap[K, V]) MoveToBack(k K) bool {
+	e, ok := m.entries[k]
+	if ok {
+		m.order.MoveToBack(e)
+	}
+	return ok
+}
+
+// Keys returns the keys in order.
+func (m *OrderedMap[K, V]) Keys() []K {
+	keys := make([]K, 0, m.Len())
+	m.Range(func(k K, _ V) bool {
+		keys = append(keys, k)
+		return true
+	})
+	return keys
+}
+
+// Values returns the values in order.
+func (m *OrderedMap[K, V]) Values() []V {
+	values := make([]V, 0, m.Len())
+	m.Range(func(_ K, v V) bool {
+		values = append(values, v)
+		return true
+	})
+	return values
+}
+
+// All returns an iter.Seq2 over the entries in order.
+func (m *OrderedMap[K, V]) All() iter.Seq2[K, V] {
+	return KeyedRangerSeq2[K, V](m)
+}
+
+// Range calls the given function for all entries in order.
+//
+// Interface: KeyedRanger.
+func (m *OrderedMap[K, V]) Range(predicate KeyedPredicate[K, V]) {
+	if m.order == nil {
+		return
+	}
+	for e := m.order.Front(); e != nil; e = e.Next() {
+		entry := e.Value.(*orderedMapEntry[K, V])
+		if !predicate(entry.key, entry.value) {
+			return
+		}
+	}
+}
+
+// MarshalJSON encodes the map as an object with keys in order, or null for a nil map.
+//
+// Interface: json.Marshaler.
+func (m *OrderedMap[K, V]) MarshalJSON() ([]byte, error) {
+	if m == nil {
+		return []byte("null"), nil
+	}
+
+	var (
+		buf bytes.Buffer
+		err error
+	)
+	buf.WriteByte('{')
+	m.Range(func(k K, v V) bool {
+		var key, value []byte
+		if key, err = orderedMapMarshalKey(k); err != nil {
+			return false
+		}
+		if value, err = json.Marshal(v); err != nil {
+			return false
+		}
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON decodes the map from an object, keeping keys in order. Like
+// encoding/json, null leaves the map unchanged.
+//
+// Interface: json.Unmarshaler.
+func (m *OrderedMap[K, V]) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok == nil {
+		return nil
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return ErrOrderedMapInvalidJSON.Wrapf("token=%v", tok)
+	}
+
+	m.entries = make(map[K]*list.Element)
+	m.order = list.New()
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		k, err := orderedMapUnmarshalKey[K](tok.(string))
+		if err != nil {
+			return err
+		}
+		var v V
+		if err := dec.Decode(&v); err != nil {
+			return err
+		}
+		m.Set(k, v)
+	}
+	_, err = dec.Token()
+	return err
+}
+
+// init lazily initialises a zero value map.
+func (m *OrderedMap[K, V]) init() {
+	if m.entries == nil {
+		m.entries = make(map[K]*list.Element)
+		m.order = list.New()
+	}
+}
+
+// OrderedMapFilter will return a new ordered map containing only items
+// from the input map that match the predicate function, in the same order.
+func OrderedMapFilter[K comparable, V any](input *OrderedMap[K, V], predicate KeyedPredicate[K, V]) *OrderedMap[K, V] {
+	filtered := NewOrderedMap[K, V]()
+	input.Range(func(k K, v V) bool {
+		if predicate(k, v) {
+			filtered.Set(k, v)
+		}
+		return true
+	})
+	return filtered
+}
+
+// orderedMapEntry is a key/value pair stored in the order list.
+type orderedMapEntry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// orderedMapMarshalKey encodes the key as a JSON object key, quoting
+// non-string encodings such as numbers.
+func orderedMapMarshalKey[K comparable](k K) ([]byte, error) {
+	b, err := json.Marshal(k)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) > 0 && b[0] == '"' {
+		return b, nil
+	}
+	return json.Marshal(string(b))
+}
+
+// orderedMapUnmarshalKey decodes the JSON object key, falling back to the
+// unquoted form for non-string keys such as numbers.
+func orderedMapUnmarshalKey[K comparable](s string) (K, error) {
+	var k K
+	quoted, err := json.Marshal(s)
+	if err != nil {
+		return k, err
+	}
+	if err := json.Unmarshal(quoted, &k); err == nil {
+		return k, nil
+	}
+	if err := json.Unmarshal([]byte(s), &k); err != nil {
+		return k, err
+	}
+	return k, nil
+}
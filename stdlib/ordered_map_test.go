@@ -0,0 +1,74 @@
+package stdlib_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ahawker/stdlibx-go/stdlib"
+	"github.com/ahawker/stdlibx-go/stdtest"
+)
+
+func TestOrderedMap(t *testing.T) {
+	test := stdtest.NewTest(t)
+
+	m := stdlib.NewOrderedMap[string, int]()
+	m.Set("c", 3)
+	m.Set("a", 1)
+	m.Set("b", 2)
+	m.Set("a", 10)
+	test.Equal(m.Keys(), []string{"c", "a", "b"})
+	test.Equal(m.Values(), []int{3, 10, 2})
+
+	v, ok := m.Get("a")
+	test.True(ok, "want key present")
+	test.Equal(v, 10)
+
+	test.True(m.MoveToFront("b"), "want key moved")
+	test.True(m.MoveToBack("c"), "want key moved")
+	test.Equal(m.Keys(), []string{"b", "a", "c"})
+	test.True(m.Delete("a"), "want key deleted")
+	test.False(m.Delete("a"), "want key missing")
+	test.Equal(m.Len(), 2)
+
+	filtered := stdlib.OrderedMapFilter(m, func(k string, v int) bool { return v > 2 })
+	test.Equal(filtered.Keys(), []string{"c"})
+
+	data, err := json.Marshal(m)
+	test.OK(err)
+	test.Equal(string(data), `{"b":2,"c":3}`)
+
+	decoded := stdlib.NewOrderedMap[string, int]()
+	test.OK(json.Unmarshal([]byte(`{"z":1,"y":2,"x":3}`), decoded))
+	test.Equal(decoded.Keys(), []string{"z", "y", "x"})
+
+	// Non-string keys are quoted.
+	var numbers stdlib.OrderedMap[int, string]
+	numbers.Set(2, "two")
+	numbers.Set(1, "one")
+	data, err = json.Marshal(&numbers)
+	test.OK(err)
+	test.Equal(string(data), `{"2":"two","1":"one"}`)
+	var roundtrip stdlib.OrderedMap[int, string]
+	test.OK(json.Unmarshal(data, &roundtrip))
+	test.Equal(roundtrip.Keys(), []int{2, 1})
+
+	// Keys with control characters round trip as JSON escapes.
+	control := stdlib.NewOrderedMap[string, int]()
+	control.Set("bell\a", 1)
+	control.Set("del\x7f", 2)
+	data, err = json.Marshal(control)
+	test.OK(err)
+	test.Equal(string(data), "{\"bell\\u0007\":1,\"del\x7f\":2}")
+	escaped := stdlib.NewOrderedMap[string, int]()
+	test.OK(json.Unmarshal(data, escaped))
+	test.Equal(escaped.Keys(), []string{"bell\a", "del\x7f"})
+
+	// Null encodes a nil map and leaves a decoded map unchanged.
+	var missing *stdlib.OrderedMap[string, int]
+	data, err = missing.MarshalJSON()
+	test.OK(err)
+	test.Equal(string(data), "null")
+	test.OK(decoded.UnmarshalJSON([]byte("null")))
+	test.Equal(decoded.Keys(), []string{"z", "y", "x"})
+	test.EqualError(decoded.UnmarshalJSON([]byte("[1]")), stdlib.ErrOrderedMapInvalidJSON)
+}
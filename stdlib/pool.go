@@ -0,0 +1,414 @@
+package stdlib
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+var _ io.Closer = (*Pool[io.Closer])(nil)
+
+// DefaultPoolMaxSize is the default max number of resources in a pool.
+var DefaultPoolMaxSize = 10
+
+// ErrPoolExhausted is returned when no pool resource became available before
+// the context was done.
+var ErrPoolExhausted = Error{
+	Code:      "pool_exhausted",
+	Flags:     ErrorFlagRetryable,
+	Message:   "pool has no available resources",
+	Namespace: ErrorNamespaceDefault,
+}
+
+// ErrPoolHealthCheckFailed is returned when a pooled resource fails its health
+// check on borrow. The resource is destroyed.
+var ErrPoolHealthCheckFailed = Error{
+	Code:      "pool_health_check_failed",
+	Flags:     ErrorFlagRetryable,
+	Message:   "pool resource failed its health check",
+	Namespace: ErrorNamespaceDefault,
+}
+
+// ErrPoolClosed is returned when acquiring a resource from a closed pool.
+var ErrPoolClosed = Error{
+	Code:      "pool_closed",
+	Message:   "pool is closed",
+	Namespace: ErrorNamespaceDefault,
+}
+
+// ErrPoolConfig is returned when creating a pool with an invalid config.
+var ErrPoolConfig = Error{
+	Code:      "pool_config",
+	Message:   "pool config is invalid",
+	Namespace: ErrorNamespaceDefault,
+}
+
+// PoolConfig for a resource pool.
+type PoolConfig[T io.Closer] struct {
+	// MinSize is the number of resources created up front and kept when reaping.
+	MinSize int
+	// MaxSize is the max number of resources, idle or in use.
+	MaxSize int
+	// IdleTimeout is how long a resource can stay idle. Zero is forever.
+	IdleTimeout time.Duration
+	// MaxLifetime is how long a resource can exist. Zero is forever.
+	MaxLifetime time.Duration
+	// HealthCheck is called on idle resources when borrowed.
+	HealthCheck func(ctx context.Context, t T) error
+	// Clock is the source of time for expiry.
+	Clock Clock
+}
+
+// WithPoolMinSize sets the number of resources created up front.
+func WithPoolMinSize[T io.Closer](size int) Option[*PoolConfig[T]] {
+	return func(o *PoolConfig[T]) error {
+		o.MinSize = size
+		return nil
+	}
+}
+
+// WithPoolMaxSize sets the max number of resources.
+func WithPoolMaxSize[T io.Closer](size int) Option[*PoolConfig[T]] {
+	return func(o *PoolConfig[T]) error {
+		o.MaxSize = size
+		return nil
+	}
+}
+
+// WithPoolIdleTimeout sets how long a resource can stay idle.
+func WithPoolIdleTimeout[T io.Closer](timeout time.Duration) Option[*PoolConfig[T]] {
+	return func(o *PoolConfig[T]) error {
+		o.IdleTimeout = timeout
+		return nil
+	}
+}
+
+// WithPoolMaxLifetime sets how long a resource can exist.
+func WithPoolMaxLifetime[T io.Closer](lifetime time.Duration) Option[*PoolConfig[T]] {
+	return func(o *PoolConfig[T]) error {
+		o.MaxLifetime = lifetime
+		return nil
+	}
+}
+
+// WithPoolHealthCheck sets the health check called on idle resources when borrowed.
+func WithPoolHealthCheck[T io.Closer](fn func(ctx context.Context, t T) error) Option[*PoolConfig[T]] {
+	return func(o *PoolConfig[T]) error {
+		o.HealthCheck = fn
+		return nil
+	}
+}
+
+// WithPoolClock sets the clock used for expiry.
+func WithPoolClock[T io.Closer](clock Clock) Option[*PoolConfig[T]] {
+	return func(o *PoolConfig[T]) error {
+		o.Clock = clock
+		return nil
+	}
+}
+
+// PoolStats describe the resources in a pool.
+type PoolStats struct {
+	// Size is the number of resources, idle or in use.
+	Size int
+	// Idle is the number of resources available to borrow.
+	Idle int
+	// InUse is the number of borrowed resources.
+	InUse int
+}
+
+// NewPool creates a new *Pool for the factory and options, creating the
+// min number of resources up front.
+func NewPool[T io.Closer](
+	ctx context.Context,
+	factory func(ctx context.Context) (T, error),
+	options ...Option[*PoolConfig[T]],
+) (*Pool[T], error) {
+	cfg, err := OptionApply(&PoolConfig[T]{
+		MaxSize: DefaultPoolMaxSize,
+		Clock:   DefaultClock,
+	}, options...)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.MaxSize <= 0 {
+		return nil, ErrPoolConfig.Wrapf("max_size=%d must be positive", cfg.MaxSize)
+	}
+	if cfg.MinSize > cfg.MaxSize {
+		return nil, ErrPoolConfig.Wrapf("min_size=%d exceeds max_size=%d", cfg.MinSize, cfg.MaxSize)
+	}
+
+	p := &Pool[T]{
+		factory: factory,
+		config:  cfg,
+		slots:   make(chan struct{}, cfg.MaxSize),
+		idle:    make(chan *poolItem[T], cfg.MaxSize),
+		done:    make(chan struct{}),
+	}
+	for i := 0; i < cfg.MinSize; i++ {
+		p.slots <- struct{}{}
+		item, err := p.create(ctx)
+		if err != nil {
+			return nil, ErrorJoin(err, p.Close()).ErrorOrNil()
+		}
+		p.idle <- item
+	}
+	if interval := p.reapInterval(); interval > 0 {
+		ticker := cfg.Clock.NewTicker(interval)
+		p.reaper = ticker
+		go p.reap(ticker)
+	}
+	return p, nil
+}
+
+// Pool is a thread safe pool of reusable resources.
+type Pool[T io.Closer] struct {
+	// factory creates new resources.
+	factory func(ctx context.Context) (T, error)
+	// config for size, expiry and health checks.
+	config *PoolConfig[T]
+	// slots holds a token for every resource, bounding the size of the pool.
+	slots chan struct{}
+	// idle are the resources available to borrow.
+	idle chan *poolItem[T]
+	// done is closed when the pool is closed.
+	done chan struct{}
+	// reaper triggers removal of expired idle resources.
+	reaper Ticker
+	// mu guards closed and sends on idle.
+	mu sync.Mutex
+	// closed is true once the pool has been closed.
+	closed bool
+	// once guards closing the pool.
+	once sync.Once
+	// err is the result of closing the pool.
+	err error
+}
+
+// Acquire borrows a resource from the pool, creating one if none are idle and
+// the pool is not full. It waits for a resource until the context is done.
+func (p *Pool[T]) Acquire(ctx context.Context) (*PoolResource[T], error) {
+	for {
+		select {
+		case <-p.done:
+			return nil, ErrPoolClosed
+		default:
+		}
+
+		// Prefer idle resources, then new ones, then wait for either.
+		var item *poolItem[T]
+		select {
+		case item = <-p.idle:
+		default:
+			select {
+			case item = <-p.idle:
+			case p.slots <- struct{}{}:
+			case <-p.done:
+				return nil, ErrPoolClosed
+			case <-ctx.Done():
+				return nil, ErrPoolExhausted.Wrap(context.Cause(ctx))
+			}
+		}
+
+		if item == nil {
+			created, err := p.create(ctx)
+			if err != nil {
+				return nil, err
+			}
+			if p.isClosed() {
+				return nil, ErrPoolClosed.Wrap(p.destroy(created))
+			}
+			return p.borrow(created), nil
+		}
+
+		if p.expired(item, p.config.Clock.Now()) {
+			_ = p.destroy(item)
+			continue
+		}
+		if p.config.HealthCheck != nil {
+			if err := p.config.HealthCheck(ctx, item.value); err != nil {
+				return nil, ErrPoolHealthCheckFailed.Wrap(ErrorJoin(err, p.destroy(item)).ErrorOrNil())
+			}
+		}
+		return p.borrow(item), nil
+	}
+}
+
+// Stats returns the current resource counts of the pool.
+func (p *Pool[T]) Stats() PoolStats {
+	size, idle := len(p.slots), len(p.idle)
+	return PoolStats{Size: size, Idle: idle, InUse: size - idle}
+}
+
+// Close closes all idle resources through a CloserGroup. Borrowed resources
+// are closed when released.
+//
+// Interface: io.Closer.
+func (p *Pool[T]) Close() error {
+	p.once.Do(func() {
+		p.mu.Lock()
+		p.closed = true
+		close(p.done)
+		p.mu.Unlock()
+
+		if p.reaper != nil {
+			p.reaper.Stop()
+		}
+
+		group := NewCloserGroup()
+		for i := 0; ; i++ {
+			select {
+			case item := <-p.idle:
+				group.AppendNamed(fmt.Sprintf("pool[%d]", i), CloserFn(func() error {
+					return p.destroy(item)
+				}))
+				continue
+			default:
+			}
+			break
+		}
+		p.err = group.Close()
+	})
+	return p.err
+}
+
+// create a new resource using a slot already held by the caller.
+func (p *Pool[T]) create(ctx context.Context) (*poolItem[T], error) {
+	value, err := p.factory(ctx)
+	if err != nil {
+		<-p.slots
+		return nil, err
+	}
+	now := p.config.Clock.Now()
+	return &poolItem[T]{value: value, created: now, used: now}, nil
+}
+
+// isClosed returns true if the pool has been closed.
+func (p *Pool[T]) isClosed() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.closed
+}
+
+// borrow wraps the item as a resource handed out to a caller.
+func (p *Pool[T]) borrow(item *poolItem[T]) *PoolResource[T] {
+	return &PoolResource[T]{pool: p, item: item}
+}
+
+// release returns the item to the idle resources, destroying it if the pool
+// is closed or the item has expired.
+func (p *Pool[T]) release(item *poolItem[T]) error {
+	now := p.config.Clock.Now()
+	item.used = now
+
+	p.mu.Lock()
+	if !p.closed && !p.expired(item, now) {
+		p.idle <- item
+		p.mu.Unlock()
+		return nil
+	}
+	p.mu.Unlock()
+	return p.destroy(item)
+}
+
+// destroy closes the item and frees its slot.
+func (p *Pool[T]) destroy(item *poolItem[T]) error {
+	defer func() { <-p.slots }()
+	return item.value.Close()
+}
+
+// expired returns true if the item has exceeded its idle timeout or max lifetime.
+func (p *Pool[T]) expired(item *poolItem[T], now time.Time) bool {
+	if p.config.MaxLifetime > 0 && now.Sub(item.created) >= p.config.MaxLifetime {
+		return true
+	}
+	return p.config.IdleTimeout > 0 && now.Sub(item.used) >= p.config.IdleTimeout
+}
+
+// reapInterval returns how often idle resources are checked for expiry.
+func (p *Pool[T]) reapInterval() time.Duration {
+	interval := p.config.IdleTimeout
+	if lifetime := p.config.MaxLifetime; lifetime > 0 && (interval <= 0 || lifetime < interval) {
+		interval = lifetime
+	}
+	return interval / 2
+}
+
+// reap periodically destroys expired idle resources, keeping the min size.
+func (p *Pool[T]) reap(ticker Ticker) {
+	for {
+		select {
+		case <-p.done:
+			return
+		case <-ticker.C():
+		}
+
+		now := p.config.Clock.Now()
+		for n := len(p.idle); n > 0; n-- {
+			var item *poolItem[T]
+			select {
+			case item = <-p.idle:
+			default:
+			}
+			if item == nil {
+				break
+			}
+			if len(p.slots) > p.config.MinSize && p.expired(item, now) {
+				_ = p.destroy(item)
+				continue
+			}
+
+			p.mu.Lock()
+			if p.closed {
+				p.mu.Unlock()
+				_ = p.destroy(item)
+				continue
+			}
+			p.idle <- item
+			p.mu.Unlock()
+		}
+	}
+}
+
+// PoolResource is a resource borrowed from a Pool.
+type PoolResource[T io.Closer] struct {
+	// pool the resource was borrowed from.
+	pool *Pool[T]
+	// item is the pooled resource.
+	item *poolItem[T]
+	// once guards returning the resource to the pool.
+	once sync.Once
+	// err is the result of returning the resource to the pool.
+	err error
+}
+
+// Value returns the borrowed resource.
+func (r *PoolResource[T]) Value() T {
+	return r.item.value
+}
+
+// Release returns the resource to the pool and the error of closing it if the
+// pool is closed or it has expired. Subsequent calls are no-ops.
+func (r *PoolResource[T]) Release() error {
+	r.once.Do(func() { r.err = r.pool.release(r.item) })
+	return r.err
+}
+
+// Destroy closes the resource and removes it from the pool, e.g. when
+// the caller found it to be broken. Subsequent calls are no-ops.
+func (r *PoolResource[T]) Destroy() error {
+	r.once.Do(func() { r.err = r.pool.destroy(r.item) })
+	return r.err
+}
+
+// poolItem is a resource tracked by a pool.
+type poolItem[T io.Closer] struct {
+	// value is the resource.
+	value T
+	// created is when the resource was created.
+	created time.Time
+	// used is when the resource was last returned to the pool.
+	used time.Time
+}
@@ -0,0 +1,123 @@
+package stdlib_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ahawker/stdlibx-go/stdlib"
+	"github.com/ahawker/stdlibx-go/stdtest"
+)
+
+type poolConn struct {
+	id     int64
+	closed atomic.Bool
+}
+
+func (c *poolConn) Close() error {
+	c.closed.Store(true)
+	return nil
+}
+
+func TestPool(t *testing.T) {
+	test := stdtest.NewTest(t)
+	clock := stdlib.NewFakeClock(epoch)
+
+	var created atomic.Int64
+	factory := func(context.Context) (*poolConn, error) {
+		return &poolConn{id: created.Add(1)}, nil
+	}
+	healthy := true
+	pool, err := stdlib.NewPool(context.Background(), factory,
+		stdlib.WithPoolMinSize[*poolConn](1),
+		stdlib.WithPoolMaxSize[*poolConn](2),
+		stdlib.WithPoolMaxLifetime[*poolConn](time.Minute),
+		stdlib.WithPoolClock[*poolConn](clock),
+		stdlib.WithPoolHealthCheck(func(context.Context, *poolConn) error {
+			if !healthy {
+				return fmt.Errorf("unhealthy")
+			}
+			return nil
+		}),
+	)
+	test.OK(err)
+	test.Equal(pool.Stats(), stdlib.PoolStats{Size: 1, Idle: 1})
+
+	// Idle resources are reused.
+	r1, err := pool.Acquire(context.Background())
+	test.OK(err)
+	test.Equal(r1.Value().id, int64(1))
+	r2, err := pool.Acquire(context.Background())
+	test.OK(err)
+	test.Equal(r2.Value().id, int64(2))
+	test.Equal(pool.Stats(), stdlib.PoolStats{Size: 2, InUse: 2})
+
+	// Full pool waits until the context is done.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = pool.Acquire(ctx)
+	test.True(errors.Is(err, stdlib.ErrPoolExhausted), "want ErrPoolExhausted got %v", err)
+	var e stdlib.Error
+	test.True(errors.As(err, &e) && e.IsRetryable(), "want retryable error got %v", err)
+
+	// Released resources are reused; destroyed ones free a slot.
+	test.OK(r1.Release())
+	test.OK(r1.Release())
+	test.OK(r2.Destroy())
+	test.True(r2.Value().closed.Load(), "want destroyed resource closed")
+	r3, err := pool.Acquire(context.Background())
+	test.OK(err)
+	test.Equal(r3.Value().id, int64(1))
+	test.OK(r3.Release())
+
+	// Unhealthy resources are destroyed.
+	healthy = false
+	_, err = pool.Acquire(context.Background())
+	test.True(errors.Is(err, stdlib.ErrPoolHealthCheckFailed), "want ErrPoolHealthCheckFailed got %v", err)
+	test.True(r3.Value().closed.Load(), "want unhealthy resource closed")
+	healthy = true
+
+	// Expired resources are replaced.
+	r4, err := pool.Acquire(context.Background())
+	test.OK(err)
+	test.Equal(r4.Value().id, int64(3))
+	test.OK(r4.Release())
+	clock.Advance(time.Minute)
+	r5, err := pool.Acquire(context.Background())
+	test.OK(err)
+	test.Equal(r5.Value().id, int64(4))
+	test.True(r4.Value().closed.Load(), "want expired resource closed")
+	test.OK(r5.Release())
+
+	// Close closes idle resources.
+	test.OK(pool.Close())
+	test.True(r5.Value().closed.Load(), "want idle resource closed")
+	_, err = pool.Acquire(context.Background())
+	test.True(errors.Is(err, stdlib.ErrPoolClosed), "want ErrPoolClosed got %v", err)
+}
+
+func TestPoolConfig(t *testing.T) {
+	factory := func(ctx context.Context) (io.Closer, error) {
+		return stdlib.CloserFn(func() error { return nil }), nil
+	}
+	stdtest.Table[[]stdlib.Option[*stdlib.PoolConfig[io.Closer]], any]{
+		"fail: max size not positive": {
+			Got:     []stdlib.Option[*stdlib.PoolConfig[io.Closer]]{stdlib.WithPoolMaxSize[io.Closer](0)},
+			WantErr: stdlib.ErrPoolConfig,
+		},
+		"fail: min size exceeds max size": {
+			Got: []stdlib.Option[*stdlib.PoolConfig[io.Closer]]{
+				stdlib.WithPoolMinSize[io.Closer](2),
+				stdlib.WithPoolMaxSize[io.Closer](1),
+			},
+			WantErr: stdlib.ErrPoolConfig,
+		},
+	}.Run(t, func(t *stdtest.Test, tc stdtest.Testcase[[]stdlib.Option[*stdlib.PoolConfig[io.Closer]], any]) {
+		_, err := stdlib.NewPool(t.Config.Context, factory, tc.Got...)
+		t.EqualError(err, tc.WantErr)
+	})
+}
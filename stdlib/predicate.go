@@ -6,3 +6,11 @@ type Predicate[T any] func(t T) bool
 // KeyedPredicate describes functions which return true/false based on a given
 // key/value input.
 type KeyedPredicate[K comparable, V any] func(k K, v V) bool
+
+// PredicateE describes functions which return true/false based on a given
+// input that can fail.
+type PredicateE[T any] func(t T) (bool, error)
+
+// KeyedPredicateE describes functions which return true/false based on a given
+// key/value input that can fail.
+type KeyedPredicateE[K comparable, V any] func(k K, v V) (bool, error)
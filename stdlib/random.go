@@ -82,10 +82,10 @@ func RandomSelection[T any](r *Random, items []T) T {
 // RandomExcluding generates a random value that is not in the excluded set.
 //
 // Note: Depending on the content of the excluded set, this function may be extremely inefficient.
-func RandomExcluding[T comparable](fn func() T, exclude map[T]struct{}) T {
+func RandomExcluding[T comparable](fn func() T, exclude Set[T]) T {
 	for {
 		value := fn()
-		if _, ok := exclude[value]; !ok {
+		if !exclude.Contains(value) {
 			return value
 		}
 	}
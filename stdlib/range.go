@@ -17,3 +17,23 @@ type KeyedRanger[K comparable, V any] interface {
 	// If Range returns `false`, iteration will stop.
 	Range(predicate KeyedPredicate[K, V])
 }
+
+// rangerFn adapts a function to the Ranger interface.
+type rangerFn[T any] func(predicate Predicate[T])
+
+// Range calls the function with the predicate.
+//
+// Interface: Ranger.
+func (fn rangerFn[T]) Range(predicate Predicate[T]) {
+	fn(predicate)
+}
+
+// keyedRangerFn adapts a function to the KeyedRanger interface.
+type keyedRangerFn[K comparable, V any] func(predicate KeyedPredicate[K, V])
+
+// Range calls the function with the predicate.
+//
+// Interface: KeyedRanger.
+func (fn keyedRangerFn[K, V]) Range(predicate KeyedPredicate[K, V]) {
+	fn(predicate)
+}
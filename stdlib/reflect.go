@@ -1,6 +1,9 @@
 package stdlib
 
-import "reflect"
+import (
+	"cmp"
+	"reflect"
+)
 
 // AnyTo converts an any interface value to a value
 // that can be type asserted to type T.
@@ -26,3 +29,55 @@ func AnyTo[T any](v any) any {
 		}
 	}
 }
+
+// reflectCompare returns -1, 0 or +1 if a is less than, equal to or greater than b,
+// like 'cmp.Compare', and false if neither is a number or string.
+//
+// Signed integers, unsigned integers, floats and strings are ordered by value within
+// their class and sort in that class order before other values, so the ordering is
+// total when callers fall back to comparing other values by text.
+func reflectCompare(a, b reflect.Value) (int, bool) {
+	ca, cb := reflectCompareClass(a), reflectCompareClass(b)
+	switch {
+	case ca != cb:
+		return cmp.Compare(ca, cb), true
+	case ca == reflectClassSigned:
+		return cmp.Compare(a.Int(), b.Int()), true
+	case ca == reflectClassUnsigned:
+		return cmp.Compare(a.Uint(), b.Uint()), true
+	case ca == reflectClassFloat:
+		return cmp.Compare(a.Float(), b.Float()), true
+	case ca == reflectClassString:
+		return cmp.Compare(a.String(), b.String()), true
+	default:
+		return 0, false
+	}
+}
+
+// Classes of values ordered by reflectCompare, in their sort order.
+const (
+	reflectClassSigned = iota
+	reflectClassUnsigned
+	reflectClassFloat
+	reflectClassString
+	reflectClassOther
+)
+
+// reflectCompareClass returns the reflectCompare class of the value.
+func reflectCompareClass(v reflect.Value) int {
+	if !v.IsValid() {
+		return reflectClassOther
+	}
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return reflectClassSigned
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return reflectClassUnsigned
+	case reflect.Float32, reflect.Float64:
+		return reflectClassFloat
+	case reflect.String:
+		return reflectClassString
+	default:
+		return reflectClassOther
+	}
+}
@@ -0,0 +1,288 @@
+//go:generate go-enum --marshal --names
+package stdlib
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+)
+
+var (
+	_ io.Closer = (*Scheduler)(nil)
+	_ Schedule  = (*IntervalSchedule)(nil)
+)
+
+// DefaultSchedulerQueueSize is the default number of runs that can be queued for a
+// job using the 'queue' overlap policy.
+var DefaultSchedulerQueueSize = 1
+
+// ErrSchedulerClosed is returned when attempting to add a job to a closed Scheduler.
+var ErrSchedulerClosed = Error{
+	Code:      "scheduler_closed",
+	Message:   "scheduler is closed",
+	Namespace: ErrorNamespaceDefault,
+}
+
+// SchedulerOverlap is the policy applied when a job is triggered while a
+// previous run of it is still active.
+//
+// skip: Skip the new run.
+// queue: Queue the new run until the active one completes.
+// allow: Start the new run concurrently.
+//
+// ENUM(skip, queue, allow).
+type SchedulerOverlap string
+
+// Schedule describes when a job should run.
+type Schedule interface {
+	// Next returns the next time after t the job should run. The zero time
+	// indicates the job should not run again.
+	Next(t time.Time) time.Time
+}
+
+// ScheduleEvery returns a Schedule that runs at a fixed interval, offset
+// by a random duration in [0, jitter).
+func ScheduleEvery(interval, jitter time.Duration) *IntervalSchedule {
+	return &IntervalSchedule{Interval: interval, Jitter: jitter}
+}
+
+// IntervalSchedule is a Schedule that runs at a fixed interval with optional jitter.
+type IntervalSchedule struct {
+	// Interval between runs.
+	Interval time.Duration
+	// Jitter is the max random duration added to each interval.
+	Jitter time.Duration
+}
+
+// Next returns the next time after t the job should run.
+func (s *IntervalSchedule) Next(t time.Time) time.Time {
+	if s.Interval <= 0 {
+		return time.Time{}
+	}
+	if s.Jitter <= 0 {
+		return t.Add(s.Interval)
+	}
+
+	random := GetGlobal()
+	defer ReturnGlobal(random)
+
+	return t.Add(s.Interval + time.Duration(random.Rand.Int63n(int64(s.Jitter))))
+}
+
+// SchedulerConfig for a scheduler.
+type SchedulerConfig struct {
+	// Clock is the source of time used to trigger jobs.
+	Clock Clock
+}
+
+// WithSchedulerClock sets the clock used to trigger jobs.
+func WithSchedulerClock(clock Clock) Option[*SchedulerConfig] {
+	return func(o *SchedulerConfig) error {
+		o.Clock = clock
+		return nil
+	}
+}
+
+// SchedulerJobConfig for a single scheduled job.
+type SchedulerJobConfig struct {
+	// Overlap is the policy applied when the job triggers while a previous run is active.
+	Overlap SchedulerOverlap
+	// QueueSize is the max number of pending runs when using the 'queue' overlap policy.
+	// Runs triggered while the queue is full are skipped.
+	QueueSize int
+	// Task options applied to every run of the job, after the scheduler clock and job name.
+	Task []Option[*TaskConfig]
+}
+
+// WithSchedulerJobOverlap sets the overlap policy of the job.
+func WithSchedulerJobOverlap(overlap SchedulerOverlap) Option[*SchedulerJobConfig] {
+	return func(o *SchedulerJobConfig) error {
+		if !overlap.IsValid() {
+			return ErrInvalidSchedulerOverlap
+		}
+		o.Overlap = overlap
+		return nil
+	}
+}
+
+// WithSchedulerJobQueueSize sets the max number of pending runs of the job.
+func WithSchedulerJobQueueSize(size int) Option[*SchedulerJobConfig] {
+	return func(o *SchedulerJobConfig) error {
+		o.QueueSize = size
+		return nil
+	}
+}
+
+// WithSchedulerJobTask sets the task options applied to every run of the job.
+func WithSchedulerJobTask(options ...Option[*TaskConfig]) Option[*SchedulerJobConfig] {
+	return func(o *SchedulerJobConfig) error {
+		o.Task = append(o.Task, options...)
+		return nil
+	}
+}
+
+// NewScheduler creates a new *Scheduler whose runs derive their context from ctx.
+func NewScheduler(ctx context.Context, options ...Option[*SchedulerConfig]) (*Scheduler, error) {
+	cfg, err := OptionApply(&SchedulerConfig{Clock: DefaultClock}, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Scheduler{config: cfg}
+	s.ctx, s.cancel = context.WithCancel(ctx)
+	return s, nil
+}
+
+// Scheduler runs tasks at fixed intervals or from cron expressions.
+//
+// Each run executes with 'Task' so timeout and cancel semantics apply. Closing
+// the scheduler stops all jobs and waits for active runs to return, which
+// allows it to be used within a CloserGroup.
+type Scheduler struct {
+	// config for the scheduler.
+	config *SchedulerConfig
+	// ctx is the parent for all runs and is cancelled on Close.
+	ctx context.Context
+	// cancel cancels ctx.
+	cancel context.CancelFunc
+	// wg tracks job loops and active runs.
+	wg sync.WaitGroup
+	// mu guards closed.
+	mu sync.Mutex
+	// closed is true once Close has been called.
+	closed bool
+}
+
+// Add schedules the named task to run on the given schedule.
+func (s *Scheduler) Add(
+	name string,
+	schedule Schedule,
+	task TaskFn,
+	options ...Option[*SchedulerJobConfig],
+) error {
+	cfg, err := OptionApply(&SchedulerJobConfig{
+		Overlap:   SchedulerOverlapSkip,
+		QueueSize: DefaultSchedulerQueueSize,
+	}, options...)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return ErrSchedulerClosed.Wrapf("job=%s", name)
+	}
+
+	job := &schedulerJob{
+		name:     name,
+		schedule: schedule,
+		task:     task,
+		config:   cfg,
+		options:  append([]Option[*TaskConfig]{WithTaskClock(s.config.Clock), WithTaskName(name)}, cfg.Task...),
+	}
+	if cfg.Overlap == SchedulerOverlapQueue {
+		job.queue = make(chan struct{}, max(cfg.QueueSize, 1))
+		s.wg.Add(1)
+		go s.drain(job)
+	}
+
+	s.wg.Add(1)
+	go s.loop(job)
+	return nil
+}
+
+// Close stops all jobs and waits for active runs to return.
+//
+// Interface: io.Closer.
+func (s *Scheduler) Close() error {
+	s.mu.Lock()
+	s.closed = true
+	s.mu.Unlock()
+
+	s.cancel()
+	s.wg.Wait()
+	return nil
+}
+
+// loop waits for the next scheduled time of the job and triggers it until
+// the scheduler is closed or the schedule ends.
+func (s *Scheduler) loop(job *schedulerJob) {
+	defer s.wg.Done()
+
+	clock := s.config.Clock
+	next := job.schedule.Next(clock.Now())
+	for !next.IsZero() {
+		timer := clock.NewTimer(next.Sub(clock.Now()))
+		select {
+		case <-s.ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C():
+			s.trigger(job)
+			next = job.schedule.Next(clock.Now())
+		}
+	}
+}
+
+// trigger starts a run of the job per its overlap policy.
+func (s *Scheduler) trigger(job *schedulerJob) {
+	switch job.config.Overlap {
+	case SchedulerOverlapQueue:
+		select {
+		case job.queue <- struct{}{}:
+		default:
+		}
+	case SchedulerOverlapAllow:
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			job.run(s.ctx)
+		}()
+	default:
+		if !job.running.TryLock() {
+			return
+		}
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			defer job.running.Unlock()
+			job.run(s.ctx)
+		}()
+	}
+}
+
+// drain runs queued triggers of the job sequentially.
+func (s *Scheduler) drain(job *schedulerJob) {
+	defer s.wg.Done()
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-job.queue:
+			job.run(s.ctx)
+		}
+	}
+}
+
+// schedulerJob is a task registered with a Scheduler.
+type schedulerJob struct {
+	name     string
+	schedule Schedule
+	task     TaskFn
+	config   *SchedulerJobConfig
+	options  []Option[*TaskConfig]
+	// running is held while a run is active for the 'skip' overlap policy.
+	running sync.Mutex
+	// queue holds pending runs for the 'queue' overlap policy.
+	queue chan struct{}
+}
+
+// run executes the job task once.
+//
+// Errors are surfaced through the task hooks (e.g. 'WithTaskOnError').
+func (j *schedulerJob) run(ctx context.Context) {
+	_ = Task(ctx, j.task, j.options...)
+}
@@ -0,0 +1,77 @@
+// Code generated by go-enum DO NOT EDIT.
+// Version: 0.6.0
+// Revision: 919e61c0174b91303753ee3898569a01abb32c97
+// Build Date: 2023-12-18T15:54:43Z
+// Built By: goreleaser
+
+package stdlib
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// SchedulerOverlapSkip is a SchedulerOverlap of type skip.
+	SchedulerOverlapSkip SchedulerOverlap = "skip"
+	// SchedulerOverlapQueue is a SchedulerOverlap of type queue.
+	SchedulerOverlapQueue SchedulerOverlap = "queue"
+	// SchedulerOverlapAllow is a SchedulerOverlap of type allow.
+	SchedulerOverlapAllow SchedulerOverlap = "allow"
+)
+
+var ErrInvalidSchedulerOverlap = fmt.Errorf("not a valid SchedulerOverlap, try [%s]", strings.Join(_SchedulerOverlapNames, ", "))
+
+var _SchedulerOverlapNames = []string{
+	string(SchedulerOverlapSkip),
+	string(SchedulerOverlapQueue),
+	string(SchedulerOverlapAllow),
+}
+
+// SchedulerOverlapNames returns a list of possible string values of SchedulerOverlap.
+func SchedulerOverlapNames() []string {
+	tmp := make([]string, len(_SchedulerOverlapNames))
+	copy(tmp, _SchedulerOverlapNames)
+	return tmp
+}
+
+// String implements the Stringer interface.
+func (x SchedulerOverlap) String() string {
+	return string(x)
+}
+
+// IsValid provides a quick way to determine if the typed value is
+// part of the allowed enumerated values
+func (x SchedulerOverlap) IsValid() bool {
+	_, err := ParseSchedulerOverlap(string(x))
+	return err == nil
+}
+
+var _SchedulerOverlapValue = map[string]SchedulerOverlap{
+	"skip":  SchedulerOverlapSkip,
+	"queue": SchedulerOverlapQueue,
+	"allow": SchedulerOverlapAllow,
+}
+
+// ParseSchedulerOverlap attempts to convert a string to a SchedulerOverlap.
+func ParseSchedulerOverlap(name string) (SchedulerOverlap, error) {
+	if x, ok := _SchedulerOverlapValue[name]; ok {
+		return x, nil
+	}
+	return SchedulerOverlap(""), fmt.Errorf("%s is %w", name, ErrInvalidSchedulerOverlap)
+}
+
+// MarshalText implements the text marshaller method.
+func (x SchedulerOverlap) MarshalText() ([]byte, error) {
+	return []byte(string(x)), nil
+}
+
+// UnmarshalText implements the text unmarshaller method.
+func (x *SchedulerOverlap) UnmarshalText(text []byte) error {
+	tmp, err := ParseSchedulerOverlap(string(text))
+	if err != nil {
+		return err
+	}
+	*x = tmp
+	return nil
+}
@@ -0,0 +1,171 @@
+package stdlib_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ahawker/stdlibx-go/stdlib"
+	"github.com/ahawker/stdlibx-go/stdtest"
+)
+
+func TestSchedulerOverlap(t *testing.T) {
+	type Got struct {
+		triggers int
+		options  []stdlib.Option[*stdlib.SchedulerJobConfig]
+	}
+	type Want struct {
+		runs int32
+		peak int32
+	}
+	stdtest.Table[Got, Want]{
+		"pass: skip runs while active": {
+			Got: Got{
+				triggers: 3,
+				options: []stdlib.Option[*stdlib.SchedulerJobConfig]{
+					stdlib.WithSchedulerJobOverlap(stdlib.SchedulerOverlapSkip),
+				},
+			},
+			Want: Want{runs: 1, peak: 1},
+		},
+		"pass: queue runs while active and drop when full": {
+			Got: Got{
+				triggers: 3,
+				options: []stdlib.Option[*stdlib.SchedulerJobConfig]{
+					stdlib.WithSchedulerJobOverlap(stdlib.SchedulerOverlapQueue),
+					stdlib.WithSchedulerJobQueueSize(1),
+				},
+			},
+			Want: Want{runs: 2, peak: 1},
+		},
+		"pass: queue runs while active": {
+			Got: Got{
+				triggers: 3,
+				options: []stdlib.Option[*stdlib.SchedulerJobConfig]{
+					stdlib.WithSchedulerJobOverlap(stdlib.SchedulerOverlapQueue),
+					stdlib.WithSchedulerJobQueueSize(2),
+				},
+			},
+			Want: Want{runs: 3, peak: 1},
+		},
+		"pass: allow concurrent runs": {
+			Got: Got{
+				triggers: 3,
+				options: []stdlib.Option[*stdlib.SchedulerJobConfig]{
+					stdlib.WithSchedulerJobOverlap(stdlib.SchedulerOverlapAllow),
+				},
+			},
+			Want: Want{runs: 3, peak: 3},
+		},
+	}.Run(t, func(t *stdtest.Test, tc stdtest.Testcase[Got, Want]) {
+		clock := stdlib.NewFakeClock(epoch)
+		s, err := stdlib.NewScheduler(t.Config.Context, stdlib.WithSchedulerClock(clock))
+		t.OK(err)
+
+		var runs, running, peak atomic.Int32
+		started := make(chan struct{}, tc.Got.triggers)
+		release := make(chan struct{})
+		err = s.Add("job", stdlib.ScheduleEvery(time.Second, 0), func(ctx context.Context) error {
+			runs.Add(1)
+			n := running.Add(1)
+			defer running.Add(-1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			started <- struct{}{}
+			<-release
+			return nil
+		}, tc.Got.options...)
+		t.OK(err)
+
+		// Trigger the job while its first run is still active. The loop registers
+		// its next timer only after the trigger is handled.
+		for i := 0; i < tc.Got.triggers; i++ {
+			clock.BlockUntil(1)
+			clock.Advance(time.Second)
+			if i == 0 {
+				<-started
+			}
+		}
+		clock.BlockUntil(1)
+
+		for i := int32(1); i < tc.Want.peak; i++ {
+			<-started
+		}
+		close(release)
+		for i := tc.Want.peak; i < tc.Want.runs; i++ {
+			<-started
+		}
+		t.OK(s.Close())
+		t.Equal(runs.Load(), tc.Want.runs)
+		t.Equal(peak.Load(), tc.Want.peak)
+	})
+}
+
+func TestSchedulerTaskClock(t *testing.T) {
+	test := stdtest.NewTest(t)
+
+	clock := stdlib.NewFakeClock(epoch)
+	s, err := stdlib.NewScheduler(test.Config.Context, stdlib.WithSchedulerClock(clock))
+	test.OK(err)
+
+	timedOut := make(chan error, 1)
+	err = s.Add("job", stdlib.ScheduleEvery(time.Minute, 0), func(ctx context.Context) error {
+		<-ctx.Done()
+		return context.Cause(ctx)
+	}, stdlib.WithSchedulerJobTask(
+		stdlib.WithTaskTimeout(time.Second),
+		stdlib.WithTaskOnTimeout(func(_ context.Context, result stdlib.TaskResult) {
+			timedOut <- result.Err
+		}),
+	))
+	test.OK(err)
+
+	clock.BlockUntil(1)
+	clock.Advance(time.Minute)
+
+	// The run timeout waits on the scheduler clock alongside the next scheduled time.
+	clock.BlockUntil(2)
+	clock.Advance(time.Second)
+	test.EqualError(<-timedOut, stdlib.ErrTaskTimeout)
+	test.OK(s.Close())
+}
+
+func TestIntervalScheduleNext(t *testing.T) {
+	type Got struct {
+		interval time.Duration
+		jitter   time.Duration
+	}
+	type Want struct {
+		min time.Duration
+		max time.Duration
+	}
+	stdtest.Table[Got, Want]{
+		"pass: no interval never runs": {
+			Got: Got{jitter: time.Second},
+		},
+		"pass: no jitter is exact": {
+			Got:  Got{interval: time.Minute},
+			Want: Want{min: time.Minute, max: time.Minute},
+		},
+		"pass: jitter is within [interval, interval+jitter)": {
+			Got:  Got{interval: time.Minute, jitter: 10 * time.Second},
+			Want: Want{min: time.Minute, max: time.Minute + 10*time.Second - 1},
+		},
+	}.Run(t, func(t *stdtest.Test, tc stdtest.Testcase[Got, Want]) {
+		schedule := stdlib.ScheduleEvery(tc.Got.interval, tc.Got.jitter)
+		for i := 0; i < 1000; i++ {
+			next := schedule.Next(epoch)
+			if tc.Want.max == 0 {
+				t.True(next.IsZero(), "want zero time got %v", next)
+				continue
+			}
+			d := next.Sub(epoch)
+			t.True(d >= tc.Want.min && d <= tc.Want.max, "want [%v, %v] got %v", tc.Want.min, tc.Want.max, d)
+		}
+	})
+}
@@ -0,0 +1,280 @@
+package stdlib
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"iter"
+	"reflect"
+	"slices"
+	"sync"
+)
+
+var (
+	_ Ranger[string]   = Set[string](nil)
+	_ json.Marshaler   = Set[string](nil)
+	_ json.Unmarshaler = (*Set[string])(nil)
+	_ Ranger[string]   = (*SyncSet[string])(nil)
+	_ json.Marshaler   = (*SyncSet[string])(nil)
+)
+
+// NewSet creates a new Set containing the given items.
+func NewSet[T comparable](items ...T) Set[T] {
+	s := make(Set[T], len(items))
+	s.Add(items...)
+	return s
+}
+
+// Set is an unordered collection of unique items.
+//
+// Set algebra methods return new sets and leave the receiver unchanged.
+type Set[T comparable] map[T]struct{}
+
+// Add adds the items to the set.
+func (s Set[T]) Add(items ...T) {
+	for _, item := range items {
+		s[item] = struct{}{}
+	}
+}
+
+// Remove removes the items from the set.
+func (s Set[T]) Remove(items ...T) {
+	for _, item := range items {
+		delete(s, item)
+	}
+}
+
+// Contains returns true if the item is in the set.
+func (s Set[T]) Contains(item T) bool {
+	_, ok := s[item]
+	return ok
+}
+
+// Len returns the number of items in the set.
+func (s Set[T]) Len() int {
+	return len(s)
+}
+
+// Clone returns a copy of the set.
+func (s Set[T]) Clone() Set[T] {
+	output := make(Set[T], len(s))
+	for item := range s {
+		output[item] = struct{}{}
+	}
+	return output
+}
+
+// Union returns a set of items in either set.
+func (s Set[T]) Union(other Set[T]) Set[T] {
+	output := s.Clone()
+	for item := range other {
+		output[item] = struct{}{}
+	}
+	return output
+}
+
+// Intersection returns a set of items in both sets.
+func (s Set[T]) Intersection(other Set[T]) Set[T] {
+	small, large := s, other
+	if len(small) > len(large) {
+		small, large = large, small
+	}
+	output := make(Set[T])
+	for item := range small {
+		if large.Contains(item) {
+			output[item] = struct{}{}
+		}
+	}
+	return output
+}
+
+// Difference returns a set of items in this set but not the other.
+func (s Set[T]) Difference(other Set[T]) Set[T] {
+	output := make(Set[T])
+	for item := range s {
+		if !other.Contains(item) {
+			output[item] = struct{}{}
+		}
+	}
+	return output
+}
+
+// SymmetricDifference returns a set of items in exactly one of the sets.
+func (s Set[T]) SymmetricDifference(other Set[T]) Set[T] {
+	output := s.Difference(other)
+	for item := range other {
+		if !s.Contains(item) {
+			output[item] = struct{}{}
+		}
+	}
+	return output
+}
+
+// IsSubset returns true if all items of this set are in the other.
+func (s Set[T]) IsSubset(other Set[T]) bool {
+	if len(s) > len(other) {
+		return false
+	}
+	for item := range s {
+		if !other.Contains(item) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal returns true if both sets contain the same items.
+func (s Set[T]) Equal(other Set[T]) bool {
+	return len(s) == len(other) && s.IsSubset(other)
+}
+
+// Slice returns the items of the set in unspecified order.
+func (s Set[T]) Slice() []T {
+	output := make([]T, 0, len(s))
+	for item := range s {
+		output = append(output, item)
+	}
+	return output
+}
+
+// All returns an iter.Seq over the items of the set in unspecified order.
+func (s Set[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for item := range s {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// Range calls the given function for all items in the set.
+//
+// Interface: Ranger.
+func (s Set[T]) Range(predicate Predicate[T]) {
+	for item := range s {
+		if !predicate(item) {
+			return
+		}
+	}
+}
+
+// MarshalJSON encodes the set as an array, sorted so output is deterministic.
+// Numbers and strings are sorted in ascending order, signed integers before unsigned
+// integers, floats and strings, and other items after them by their encoded bytes.
+//
+// Interface: json.Marshaler.
+func (s Set[T]) MarshalJSON() ([]byte, error) {
+	if s == nil {
+		return []byte("null"), nil
+	}
+	type entry struct {
+		item reflect.Value
+		raw  json.RawMessage
+	}
+	entries := make([]entry, 0, len(s))
+	for item := range s {
+		b, err := json.Marshal(item)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry{item: reflect.ValueOf(item), raw: b})
+	}
+	slices.SortFunc(entries, func(a, b entry) int {
+		if c, ok := reflectCompare(a.item, b.item); ok {
+			return c
+		}
+		return bytes.Compare(a.raw, b.raw)
+	})
+
+	items := make([]json.RawMessage, len(entries))
+	for i, e := range entries {
+		items[i] = e.raw
+	}
+	return json.Marshal(items)
+}
+
+// UnmarshalJSON decodes the set from an array.
+//
+// Interface: json.Unmarshaler.
+func (s *Set[T]) UnmarshalJSON(data []byte) error {
+	var items []T
+	if err := json.Unmarshal(data, &items); err != nil {
+		return err
+	}
+	if items == nil {
+		*s = nil
+		return nil
+	}
+	*s = NewSet(items...)
+	return nil
+}
+
+// SetSorted returns the items of the set in ascending order.
+func SetSorted[T cmp.Ordered](s Set[T]) []T {
+	output := s.Slice()
+	slices.Sort(output)
+	return output
+}
+
+// NewSyncSet creates a new *SyncSet containing the given items.
+func NewSyncSet[T comparable](items ...T) *SyncSet[T] {
+	return &SyncSet[T]{set: NewSet(items...)}
+}
+
+// SyncSet is a Set that is safe for concurrent use.
+type SyncSet[T comparable] struct {
+	// mu guards set.
+	mu sync.RWMutex
+	// set of items.
+	set Set[T]
+}
+
+// Add adds the items to the set.
+func (s *SyncSet[T]) Add(items ...T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.set.Add(items...)
+}
+
+// Remove removes the items from the set.
+func (s *SyncSet[T]) Remove(items ...T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.set.Remove(items...)
+}
+
+// Contains returns true if the item is in the set.
+func (s *SyncSet[T]) Contains(item T) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.set.Contains(item)
+}
+
+// Len returns the number of items in the set.
+func (s *SyncSet[T]) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.set.Len()
+}
+
+// Snapshot returns a copy of the set.
+func (s *SyncSet[T]) Snapshot() Set[T] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.set.Clone()
+}
+
+// Range calls the given function for all items in a snapshot of the set.
+//
+// Interface: Ranger.
+func (s *SyncSet[T]) Range(predicate Predicate[T]) {
+	s.Snapshot().Range(predicate)
+}
+
+// MarshalJSON encodes a snapshot of the set as an array.
+//
+// Interface: json.Marshaler.
+func (s *SyncSet[T]) MarshalJSON() ([]byte, error) {
+	return s.Snapshot().MarshalJSON()
+}
@@ -0,0 +1,55 @@
+package stdlib_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ahawker/stdlibx-go/stdlib"
+	"github.com/ahawker/stdlibx-go/stdtest"
+)
+
+func TestSet(t *testing.T) {
+	test := stdtest.NewTest(t)
+
+	a := stdlib.SliceSet([]string{"read", "write", "read"})
+	b := stdlib.NewSet("write", "admin")
+	test.Equal(a.Len(), 2)
+	test.True(a.Contains("read"), "want read in set")
+
+	test.Equal(stdlib.SetSorted(a.Union(b)), []string{"admin", "read", "write"})
+	test.Equal(stdlib.SetSorted(a.Intersection(b)), []string{"write"})
+	test.Equal(stdlib.SetSorted(a.Difference(b)), []string{"read"})
+	test.Equal(stdlib.SetSorted(a.SymmetricDifference(b)), []string{"admin", "read"})
+	test.True(a.Intersection(b).IsSubset(a), "want intersection subset")
+	test.False(a.IsSubset(b), "want not subset")
+	test.True(a.Equal(stdlib.NewSet("write", "read")), "want equal sets")
+	test.Equal(stdlib.SliceFilterRange[string](a, func(s string) bool { return s == "read" }), []string{"read"})
+
+	data, err := json.Marshal(a.Union(b))
+	test.OK(err)
+	test.Equal(string(data), `["admin","read","write"]`)
+	var decoded stdlib.Set[string]
+	test.OK(json.Unmarshal(data, &decoded))
+	test.True(decoded.Equal(a.Union(b)), "want round trip")
+
+	// Numbers are sorted numerically rather than by their encoding.
+	data, err = json.Marshal(stdlib.NewSet(10, 2, 1, -3))
+	test.OK(err)
+	test.Equal(string(data), `[-3,1,2,10]`)
+
+	// Mixed kinds sort by class, signed before unsigned, floats and strings, then by value.
+	for range 20 {
+		data, err = json.Marshal(stdlib.NewSet[any](10, 9, int64(5), 7, int64(12), uint(3), 1.5, "a"))
+		test.OK(err)
+		test.Equal(string(data), `[5,7,9,10,12,3,1.5,"a"]`)
+	}
+
+	sync := stdlib.NewSyncSet(1, 2)
+	sync.Add(3)
+	sync.Remove(1)
+	test.Equal(stdlib.SetSorted(sync.Snapshot()), []int{2, 3})
+
+	r := stdlib.NewRandom(1)
+	value := stdlib.RandomExcluding(func() int { return r.Rand.Intn(3) }, stdlib.NewSet(0, 1))
+	test.Equal(value, 2)
+}
@@ -0,0 +1,223 @@
+package stdlib
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+var (
+	_ io.Closer     = (*Shutdown)(nil)
+	_ ContextCloser = (*ShutdownStage)(nil)
+)
+
+// DefaultShutdownTimeout is the default max duration for a single closer to
+// run during shutdown.
+var DefaultShutdownTimeout = 10 * time.Second
+
+// ShutdownConfig for a shutdown manager.
+type ShutdownConfig struct {
+	// Timeout is the default max duration for each closer to run.
+	Timeout time.Duration
+	// Logger receives shutdown progress.
+	Logger *slog.Logger
+	// Signals that trigger shutdown when calling 'Wait'.
+	Signals []os.Signal
+}
+
+// WithShutdownTimeout sets the default max duration for each closer to run.
+func WithShutdownTimeout(timeout time.Duration) Option[*ShutdownConfig] {
+	return func(o *ShutdownConfig) error {
+		o.Timeout = timeout
+		return nil
+	}
+}
+
+// WithShutdownLogger sets the logger that receives shutdown progress.
+func WithShutdownLogger(logger *slog.Logger) Option[*ShutdownConfig] {
+	return func(o *ShutdownConfig) error {
+		o.Logger = logger
+		return nil
+	}
+}
+
+// WithShutdownSignals sets the signals that trigger shutdown.
+func WithShutdownSignals(signals ...os.Signal) Option[*ShutdownConfig] {
+	return func(o *ShutdownConfig) error {
+		o.Signals = signals
+		return nil
+	}
+}
+
+// NewShutdown creates a new *Shutdown with sane defaults.
+func NewShutdown(options ...Option[*ShutdownConfig]) (*Shutdown, error) {
+	cfg, err := OptionApply(&ShutdownConfig{
+		Timeout: DefaultShutdownTimeout,
+		Logger:  slog.Default(),
+		Signals: []os.Signal{os.Interrupt, syscall.SIGTERM},
+	}, options...)
+	if err != nil {
+		return nil, err
+	}
+	return &Shutdown{config: cfg}, nil
+}
+
+// Shutdown orchestrates graceful shutdown of resources in dependency order.
+//
+// Resources are registered into named stages. Stages are closed in the reverse
+// order they were created (LIFO), so resources created first, e.g. database pools,
+// are closed last. Closers within a stage are closed concurrently, each bounded
+// by its own timeout. Errors are aggregated into an ErrorGroup tagged with the
+// name of the stage and closer that returned them.
+type Shutdown struct {
+	// config for the shutdown.
+	config *ShutdownConfig
+	// mu guards stages and closers.
+	mu sync.Mutex
+	// stages in creation order.
+	stages []*ShutdownStage
+	// closers closes the stages in reverse order, created on the first Close.
+	closers *CloserGroup
+}
+
+// Stage returns the stage with the given name, creating it if it does not exist.
+func (s *Shutdown) Stage(name string) *ShutdownStage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, stage := range s.stages {
+		if stage.name == name {
+			return stage
+		}
+	}
+	stage := &ShutdownStage{
+		name:    name,
+		timeout: s.config.Timeout,
+		log:     s.config.Logger.With(slog.String("stage", name)),
+	}
+	s.stages = append(s.stages, stage)
+	return stage
+}
+
+// Wait blocks until the context is done or a shutdown signal is received, and
+// then closes all stages.
+func (s *Shutdown) Wait(ctx context.Context) error {
+	ctx, stop := signal.NotifyContext(ctx, s.config.Signals...)
+	defer stop()
+
+	<-ctx.Done()
+	s.config.Logger.InfoContext(ctx, "shutdown triggered", slog.Any("cause", context.Cause(ctx)))
+	return s.Close()
+}
+
+// Close closes all stages in reverse order. Subsequent calls return the
+// result of the first.
+//
+// Interface: io.Closer.
+func (s *Shutdown) Close() error {
+	s.mu.Lock()
+	if s.closers != nil {
+		s.mu.Unlock()
+		return s.closers.Close()
+	}
+	s.closers = NewCloserGroup()
+	for i := len(s.stages) - 1; i >= 0; i-- {
+		s.closers.AppendContext(s.stages[i].name, s.stages[i])
+	}
+	closers, stages := s.closers, len(s.stages)
+	s.mu.Unlock()
+
+	log := s.config.Logger
+	start := time.Now()
+	log.Info("shutdown started", slog.Int("stages", stages))
+
+	err := closers.Close()
+
+	log.Info("shutdown complete",
+		slog.Duration("duration", time.Since(start)),
+		slog.Int("errors", NewErrorGroup(err).Len()),
+	)
+	return err
+}
+
+// ShutdownStage is a set of closers that are closed concurrently during shutdown.
+type ShutdownStage struct {
+	// name of the stage.
+	name string
+	// timeout is the default max duration for each closer.
+	timeout time.Duration
+	// log receives the progress of the stage.
+	log *slog.Logger
+	// mu guards closers.
+	mu sync.Mutex
+	// closers in the stage.
+	closers []shutdownCloser
+}
+
+// Add registers the named closer with the stage using the default timeout.
+//
+// Closers implementing ContextCloser receive a context bounded by the timeout.
+func (st *ShutdownStage) Add(name string, closer io.Closer) *ShutdownStage {
+	return st.AddTimeout(name, closer, st.timeout)
+}
+
+// AddTimeout registers the named closer with the stage using the given timeout.
+func (st *ShutdownStage) AddTimeout(name string, closer io.Closer, timeout time.Duration) *ShutdownStage {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	st.closers = append(st.closers, shutdownCloser{
+		NamedCloser: NamedCloser{Name: name, Closer: closer},
+		timeout:     timeout,
+	})
+	return st
+}
+
+// CloseContext closes all closers in the stage concurrently, each bounded by its timeout.
+//
+// Interface: ContextCloser.
+func (st *ShutdownStage) CloseContext(ctx context.Context) error {
+	st.mu.Lock()
+	closers := make([]shutdownCloser, len(st.closers))
+	copy(closers, st.closers)
+	st.mu.Unlock()
+
+	log := st.log
+	log.InfoContext(ctx, "shutdown stage started", slog.Int("closers", len(closers)))
+
+	onSuccess := func(ctx context.Context, result TaskResult) {
+		log.InfoContext(ctx, "closed", slog.String("closer", result.Name), slog.Duration("duration", result.Duration))
+	}
+	onError := func(ctx context.Context, result TaskResult) {
+		log.ErrorContext(ctx, "close failed",
+			slog.String("closer", result.Name),
+			slog.Duration("duration", result.Duration),
+			slog.Any("error", result.Err),
+		)
+	}
+
+	g, err := NewTaskGroup(ctx)
+	if err != nil {
+		return err
+	}
+	for _, c := range closers {
+		g.Go(c.Name, c.CloseContext,
+			WithTaskTimeout(c.timeout),
+			WithTaskAbandon(true),
+			WithTaskOnSuccess(onSuccess),
+			WithTaskOnError(onError),
+		)
+	}
+	return g.Wait()
+}
+
+// shutdownCloser is a named closer registered with a stage.
+type shutdownCloser struct {
+	NamedCloser
+	timeout time.Duration
+}
@@ -0,0 +1,58 @@
+package stdlib_test
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ahawker/stdlibx-go/stdlib"
+	"github.com/ahawker/stdlibx-go/stdtest"
+)
+
+func TestShutdownClose(t *testing.T) {
+	test := stdtest.NewTest(t)
+
+	var (
+		mu     sync.Mutex
+		closed []string
+	)
+	closer := func(name string, err error) io.Closer {
+		return stdlib.CloserFn(func() error {
+			mu.Lock()
+			defer mu.Unlock()
+			closed = append(closed, name)
+			return err
+		})
+	}
+
+	s, err := stdlib.NewShutdown(stdlib.WithShutdownLogger(slog.New(slog.NewTextHandler(io.Discard, nil))))
+	test.OK(err)
+	s.Stage("db").Add("postgres", closer("postgres", nil))
+	s.Stage("workers").Add("queue", closer("queue", fmt.Errorf("queue failed")))
+	s.Stage("http").
+		Add("api", closer("api", nil)).
+		AddTimeout("slow", stdlib.CloserFn(func() error {
+			time.Sleep(time.Second)
+			return nil
+		}), 50*time.Millisecond)
+
+	err = s.Close()
+	test.NotOK(err)
+	test.Equal(closed, []string{"api", "queue", "postgres"})
+
+	eg, ok := err.(*stdlib.ErrorGroup)
+	test.True(ok, "want *stdlib.ErrorGroup got %T", err)
+	tags := make(map[string]bool)
+	for _, e := range eg.Errors {
+		for _, tag := range e.Extras.Tags {
+			tags[tag] = true
+		}
+	}
+	test.Equal(tags, map[string]bool{"http": true, "slow": true, "workers": true, "queue": true})
+
+	// Close is idempotent.
+	test.Equal(s.Close(), err)
+}
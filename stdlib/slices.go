@@ -1,5 +1,38 @@
+//go:generate go-enum --marshal --names
 package stdlib
 
+import (
+	"runtime"
+	"slices"
+	"sync"
+)
+
+// DefaultSliceMapWorkers is the default number of workers used by 'SliceMapConcurrent'.
+var DefaultSliceMapWorkers = runtime.GOMAXPROCS(0)
+
+// ErrSliceKeyCollision is returned when multiple items map to the same key
+// using the 'error' collision policy.
+var ErrSliceKeyCollision = Error{
+	Code:      "slice_key_collision",
+	Message:   "multiple items map to the same key",
+	Namespace: ErrorNamespaceDefault,
+}
+
+// SliceKeyCollision is the policy applied when multiple items map to the same key.
+//
+// first: Keep the first item.
+// last: Keep the last item.
+// error: Return an error.
+//
+// ENUM(first, last, error).
+type SliceKeyCollision string
+
+// Pair is a two item tuple.
+type Pair[A any, B any] struct {
+	First  A
+	Second B
+}
+
 // SliceFlatten will flatten a slice of slices into a
 // single slice.
 func SliceFlatten[T any](input ...[]T) []T {
@@ -11,12 +44,8 @@ func SliceFlatten[T any](input ...[]T) []T {
 }
 
 // SliceSet returns a set from the given slice.
-func SliceSet[T comparable](input []T) map[T]struct{} {
-	output := make(map[T]struct{}, len(input))
-	for _, item := range input {
-		output[item] = struct{}{}
-	}
-	return output
+func SliceSet[T comparable](input []T) Set[T] {
+	return NewSet(input...)
 }
 
 // SliceTypeAssert takes a slice of one type and asserts individual
@@ -29,6 +58,14 @@ func SliceTypeAssert[TIn any, TOut any](input []TIn) []TOut {
 	return output
 }
 
+// SliceTypeAssertE takes a slice of one type and asserts individual
+// items to the other, returning ErrTypeAssertionFailed for items that can't be.
+func SliceTypeAssertE[TIn any, TOut any](input []TIn, options ...Option[*TransformConfig]) ([]TOut, error) {
+	return SliceMapE(input, func(item TIn) (TOut, error) {
+		return As[TOut](item)
+	}, options...)
+}
+
 // SliceMap returns a slice with the results from the given 'map' function.
 func SliceMap[TIn any, TOut any](input []TIn, mapper Mapper[TIn, TOut]) []TOut {
 	output := make([]TOut, 0, len(input))
@@ -38,6 +75,34 @@ func SliceMap[TIn any, TOut any](input []TIn, mapper Mapper[TIn, TOut]) []TOut {
 	return output
 }
 
+// SliceMapE returns a slice with the results from the given 'map' function
+// or the errors tagged with the index of the items that failed.
+func SliceMapE[TIn any, TOut any](
+	input []TIn,
+	mapper MapperE[TIn, TOut],
+	options ...Option[*TransformConfig],
+) ([]TOut, error) {
+	errs, err := newTransformErrors(options)
+	if err != nil {
+		return nil, err
+	}
+	output := make([]TOut, 0, len(input))
+	for i, item := range input {
+		out, err := mapper(item)
+		if err != nil {
+			if !errs.append(err, indexTag(i)) {
+				break
+			}
+			continue
+		}
+		output = append(output, out)
+	}
+	if err := errs.errors.ErrorOrNil(); err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
 // SliceToMap returns a map from the given slice and key function.
 func SliceToMap[K comparable, V any](input []V, key func(v V) K) map[K]V {
 	output := make(map[K]V, len(input))
@@ -47,6 +112,30 @@ func SliceToMap[K comparable, V any](input []V, key func(v V) K) map[K]V {
 	return output
 }
 
+// SliceToMapE returns a map from the given slice and key function or the
+// errors tagged with the index of the items that failed.
+func SliceToMapE[K comparable, V any](input []V, key MapperE[V, K], options ...Option[*TransformConfig]) (map[K]V, error) {
+	errs, err := newTransformErrors(options)
+	if err != nil {
+		return nil, err
+	}
+	output := make(map[K]V, len(input))
+	for i, item := range input {
+		k, err := key(item)
+		if err != nil {
+			if !errs.append(err, indexTag(i)) {
+				break
+			}
+			continue
+		}
+		output[k] = item
+	}
+	if err := errs.errors.ErrorOrNil(); err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
 // SliceFilter will return a new slice containing only items
 // from the given input that match the predicate function.
 func SliceFilter[T any](input []T, predicate Predicate[T]) []T {
@@ -59,15 +148,269 @@ func SliceFilter[T any](input []T, predicate Predicate[T]) []T {
 	return output
 }
 
+// SliceFilterE will return a new slice containing only items from the
+// given input that match the predicate function or the errors tagged with
+// the index of the items that failed.
+func SliceFilterE[T any](input []T, predicate PredicateE[T], options ...Option[*TransformConfig]) ([]T, error) {
+	errs, err := newTransformErrors(options)
+	if err != nil {
+		return nil, err
+	}
+	var output []T
+	for i, item := range input {
+		ok, err := predicate(item)
+		if err != nil {
+			if !errs.append(err, indexTag(i)) {
+				break
+			}
+			continue
+		}
+		if ok {
+			output = append(output, item)
+		}
+	}
+	if err := errs.errors.ErrorOrNil(); err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
 // SliceFilterRange will return a new slice containing only items
 // from the given input ranger that match the predicate function.
 func SliceFilterRange[T any](input Ranger[T], predicate Predicate[T]) []T {
-	var output []T
-	input.Range(func(item T) bool {
+	return SeqCollect(SeqFilter(RangerSeq(input), predicate))
+}
+
+// SliceGroupBy returns a map of items grouped by the key function, keeping
+// the order of items within each group.
+func SliceGroupBy[K comparable, V any](input []V, key Mapper[V, K]) map[K][]V {
+	output := make(map[K][]V)
+	for _, item := range input {
+		k := key(item)
+		output[k] = append(output[k], item)
+	}
+	return output
+}
+
+// SlicePartition returns the items that match the predicate and those that don't.
+func SlicePartition[T any](input []T, predicate Predicate[T]) ([]T, []T) {
+	var matched, unmatched []T
+	for _, item := range input {
 		if predicate(item) {
-			output = append(output, item)
+			matched = append(matched, item)
+		} else {
+			unmatched = append(unmatched, item)
+		}
+	}
+	return matched, unmatched
+}
+
+// SliceChunk splits the input into consecutive, non-overlapping slices of up
+// to size items. The last chunk may be shorter. Chunks share memory with the input.
+func SliceChunk[T any](input []T, size int) [][]T {
+	if size <= 0 {
+		return nil
+	}
+	output := make([][]T, 0, (len(input)+size-1)/size)
+	for i := 0; i < len(input); i += size {
+		end := min(i+size, len(input))
+		output = append(output, input[i:end:end])
+	}
+	return output
+}
+
+// SliceWindow returns overlapping slices of size consecutive items, advancing
+// one item at a time. Windows share memory with the input.
+func SliceWindow[T any](input []T, size int) [][]T {
+	if size <= 0 || size > len(input) {
+		return nil
+	}
+	output := make([][]T, 0, len(input)-size+1)
+	for i := 0; i+size <= len(input); i++ {
+		output = append(output, input[i:i+size:i+size])
+	}
+	return output
+}
+
+// SliceZip returns pairs of items at the same index, stopping at the end of
+// the shorter input.
+func SliceZip[A any, B any](a []A, b []B) []Pair[A, B] {
+	n := min(len(a), len(b))
+	output := make([]Pair[A, B], n)
+	for i := 0; i < n; i++ {
+		output[i] = Pair[A, B]{First: a[i], Second: b[i]}
+	}
+	return output
+}
+
+// SliceUnzip splits pairs into slices of their first and second items.
+func SliceUnzip[A any, B any](input []Pair[A, B]) ([]A, []B) {
+	a, b := make([]A, len(input)), make([]B, len(input))
+	for i, pair := range input {
+		a[i], b[i] = pair.First, pair.Second
+	}
+	return a, b
+}
+
+// SliceUniq returns the first occurrence of each item, in order.
+func SliceUniq[T comparable](input []T) []T {
+	return SliceUniqBy(input, func(t T) T { return t })
+}
+
+// SliceUniqBy returns the first item for each key, in order.
+func SliceUniqBy[T any, K comparable](input []T, key Mapper[T, K]) []T {
+	seen := make(map[K]struct{}, len(input))
+	output := make([]T, 0, len(input))
+	for _, item := range input {
+		k := key(item)
+		if _, ok := seen[k]; ok {
+			continue
+		}
+		seen[k] = struct{}{}
+		output = append(output, item)
+	}
+	return output
+}
+
+// SliceReduce combines all items into a single value, starting with the first
+// item. It reports false if the input is empty.
+func SliceReduce[T any](input []T, fn func(acc T, t T) T) (T, bool) {
+	if len(input) == 0 {
+		return *new(T), false
+	}
+	return SliceFold(input[1:], input[0], fn), true
+}
+
+// SliceFold combines all items into a single value, starting with initial.
+func SliceFold[T any, A any](input []T, initial A, fn func(acc A, t T) A) A {
+	return SeqReduce(slices.Values(input), initial, fn)
+}
+
+// SliceFind returns the first item that matches the predicate and reports
+// whether one was found.
+func SliceFind[T any](input []T, predicate Predicate[T]) (T, bool) {
+	if i := SliceFindIndex(input, predicate); i >= 0 {
+		return input[i], true
+	}
+	return *new(T), false
+}
+
+// SliceFindIndex returns the index of the first item that matches the
+// predicate or -1 if none do.
+func SliceFindIndex[T any](input []T, predicate Predicate[T]) int {
+	return slices.IndexFunc(input, predicate)
+}
+
+// SliceAny returns true if any item matches the predicate.
+func SliceAny[T any](input []T, predicate Predicate[T]) bool {
+	return SliceFindIndex(input, predicate) >= 0
+}
+
+// SliceAll returns true if all items match the predicate.
+func SliceAll[T any](input []T, predicate Predicate[T]) bool {
+	for _, item := range input {
+		if !predicate(item) {
+			return false
 		}
-		return true
+	}
+	return true
+}
+
+// SliceNone returns true if no items match the predicate.
+func SliceNone[T any](input []T, predicate Predicate[T]) bool {
+	return !SliceAny(input, predicate)
+}
+
+// SliceCountBy returns the number of items for each key.
+func SliceCountBy[T any, K comparable](input []T, key Mapper[T, K]) map[K]int {
+	output := make(map[K]int)
+	for _, item := range input {
+		output[key(item)]++
+	}
+	return output
+}
+
+// SliceKeyBy returns a map of items by the key function, using the policy
+// to resolve multiple items with the same key.
+func SliceKeyBy[K comparable, V any](input []V, key Mapper[V, K], policy SliceKeyCollision) (map[K]V, error) {
+	if !policy.IsValid() {
+		return nil, ErrInvalidSliceKeyCollision
+	}
+	output := make(map[K]V, len(input))
+	for i, item := range input {
+		k := key(item)
+		if _, ok := output[k]; ok {
+			switch policy {
+			case SliceKeyCollisionFirst:
+				continue
+			case SliceKeyCollisionError:
+				return nil, ErrSliceKeyCollision.Wrapf("item %d has duplicate key %v", i, k)
+			}
+		}
+		output[k] = item
+	}
+	return output, nil
+}
+
+// SliceIntersect returns the unique items of a that are also in b, in order.
+func SliceIntersect[T comparable](a []T, b []T) []T {
+	set := SliceSet(b)
+	return SliceUniq(SliceFilter(a, set.Contains))
+}
+
+// SliceDifference returns the unique items of a that are not in b, in order.
+func SliceDifference[T comparable](a []T, b []T) []T {
+	set := SliceSet(b)
+	return SliceUniq(SliceFilter(a, func(t T) bool { return !set.Contains(t) }))
+}
+
+// SliceShuffle returns a copy of the input in random order. A nil Random
+// uses the global one.
+func SliceShuffle[T any](input []T, r *Random) []T {
+	output := slices.Clone(input)
+	if r == nil {
+		r = GetGlobal()
+		defer ReturnGlobal(r)
+	}
+	r.Rand.Shuffle(len(output), func(i, j int) {
+		output[i], output[j] = output[j], output[i]
 	})
 	return output
 }
+
+// SliceSample returns up to n items chosen at random without replacement. A
+// nil Random uses the global one.
+func SliceSample[T any](input []T, n int, r *Random) []T {
+	if n <= 0 {
+		return nil
+	}
+	return SliceShuffle(input, r)[:min(n, len(input))]
+}
+
+// SliceMapConcurrent returns a slice with the results from the given 'map'
+// function, run concurrently by up to workers goroutines. Zero or less uses
+// DefaultSliceMapWorkers.
+func SliceMapConcurrent[TIn any, TOut any](input []TIn, mapper Mapper[TIn, TOut], workers int) []TOut {
+	if workers <= 0 {
+		workers = DefaultSliceMapWorkers
+	}
+	output := make([]TOut, len(input))
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < min(workers, len(input)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				output[i] = mapper(input[i])
+			}
+		}()
+	}
+	for i := range input {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	return output
+}
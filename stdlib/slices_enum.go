@@ -0,0 +1,77 @@
+// Code generated by go-enum DO NOT EDIT.
+// Version: 0.6.0
+// Revision: 919e61c0174b91303753ee3898569a01abb32c97
+// Build Date: 2023-12-18T15:54:43Z
+// Built By: goreleaser
+
+package stdlib
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// SliceKeyCollisionFirst is a SliceKeyCollision of type first.
+	SliceKeyCollisionFirst SliceKeyCollision = "first"
+	// SliceKeyCollisionLast is a SliceKeyCollision of type last.
+	SliceKeyCollisionLast SliceKeyCollision = "last"
+	// SliceKeyCollisionError is a SliceKeyCollision of type error.
+	SliceKeyCollisionError SliceKeyCollision = "error"
+)
+
+var ErrInvalidSliceKeyCollision = fmt.Errorf("not a valid SliceKeyCollision, try [%s]", strings.Join(_SliceKeyCollisionNames, ", "))
+
+var _SliceKeyCollisionNames = []string{
+	string(SliceKeyCollisionFirst),
+	string(SliceKeyCollisionLast),
+	string(SliceKeyCollisionError),
+}
+
+// SliceKeyCollisionNames returns a list of possible string values of SliceKeyCollision.
+func SliceKeyCollisionNames() []string {
+	tmp := make([]string, len(_SliceKeyCollisionNames))
+	copy(tmp, _SliceKeyCollisionNames)
+	return tmp
+}
+
+// String implements the Stringer interface.
+func (x SliceKeyCollision) String() string {
+	return string(x)
+}
+
+// IsValid provides a quick way to determine if the typed value is
+// part of the allowed enumerated values
+func (x SliceKeyCollision) IsValid() bool {
+	_, err := ParseSliceKeyCollision(string(x))
+	return err == nil
+}
+
+var _SliceKeyCollisionValue = map[string]SliceKeyCollision{
+	"first": SliceKeyCollisionFirst,
+	"last":  SliceKeyCollisionLast,
+	"error": SliceKeyCollisionError,
+}
+
+// ParseSliceKeyCollision attempts to convert a string to a SliceKeyCollision.
+func ParseSliceKeyCollision(name string) (SliceKeyCollision, error) {
+	if x, ok := _SliceKeyCollisionValue[name]; ok {
+		return x, nil
+	}
+	return SliceKeyCollision(""), fmt.Errorf("%s is %w", name, ErrInvalidSliceKeyCollision)
+}
+
+// MarshalText implements the text marshaller method.
+func (x SliceKeyCollision) MarshalText() ([]byte, error) {
+	return []byte(string(x)), nil
+}
+
+// UnmarshalText implements the text unmarshaller method.
+func (x *SliceKeyCollision) UnmarshalText(text []byte) error {
+	tmp, err := ParseSliceKeyCollision(string(text))
+	if err != nil {
+		return err
+	}
+	*x = tmp
+	return nil
+}
@@ -0,0 +1,130 @@
+package stdlib_test
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/ahawker/stdlibx-go/stdlib"
+	"github.com/ahawker/stdlibx-go/stdtest"
+)
+
+func TestSliceAlgorithms(t *testing.T) {
+	test := stdtest.NewTest(t)
+
+	numbers := []int{1, 2, 3, 4, 5}
+	even := func(i int) bool { return i%2 == 0 }
+	parity := func(i int) string {
+		if even(i) {
+			return "even"
+		}
+		return "odd"
+	}
+
+	test.Equal(stdlib.SliceGroupBy(numbers, parity), map[string][]int{"even": {2, 4}, "odd": {1, 3, 5}})
+	matched, unmatched := stdlib.SlicePartition(numbers, even)
+	test.Equal(matched, []int{2, 4})
+	test.Equal(unmatched, []int{1, 3, 5})
+	test.Equal(stdlib.SliceChunk(numbers, 2), [][]int{{1, 2}, {3, 4}, {5}})
+	test.Equal(stdlib.SliceWindow(numbers, 4), [][]int{{1, 2, 3, 4}, {2, 3, 4, 5}})
+
+	pairs := stdlib.SliceZip([]string{"a", "b", "c"}, []int{1, 2})
+	test.Equal(pairs, []stdlib.Pair[string, int]{{"a", 1}, {"b", 2}})
+	letters, ints := stdlib.SliceUnzip(pairs)
+	test.Equal(letters, []string{"a", "b"})
+	test.Equal(ints, []int{1, 2})
+
+	test.Equal(stdlib.SliceUniq([]int{3, 1, 3, 2, 1}), []int{3, 1, 2})
+	test.Equal(stdlib.SliceUniqBy([]string{"a", "B", "A", "b"}, strings.ToLower), []string{"a", "B"})
+
+	sum, ok := stdlib.SliceReduce(numbers, func(a, b int) int { return a + b })
+	test.True(ok, "want reduced value")
+	test.Equal(sum, 15)
+	_, ok = stdlib.SliceReduce([]int{}, func(a, b int) int { return a + b })
+	test.False(ok, "want empty input")
+	test.Equal(stdlib.SliceFold(numbers, "", func(acc string, i int) string { return acc + parity(i)[:1] }), "oeoeo")
+
+	found, ok := stdlib.SliceFind(numbers, even)
+	test.True(ok, "want found item")
+	test.Equal(found, 2)
+	test.Equal(stdlib.SliceFindIndex(numbers, func(i int) bool { return i > 10 }), -1)
+	test.True(stdlib.SliceAny(numbers, even), "want any")
+	test.False(stdlib.SliceAll(numbers, even), "want not all")
+	test.True(stdlib.SliceNone(numbers, func(i int) bool { return i > 10 }), "want none")
+	test.Equal(stdlib.SliceCountBy(numbers, parity), map[string]int{"even": 2, "odd": 3})
+
+	words := []string{"apple", "avocado", "banana"}
+	first := func(s string) byte { return s[0] }
+	keyed, err := stdlib.SliceKeyBy(words, first, stdlib.SliceKeyCollisionFirst)
+	test.OK(err)
+	test.Equal(keyed, map[byte]string{'a': "apple", 'b': "banana"})
+	keyed, err = stdlib.SliceKeyBy(words, first, stdlib.SliceKeyCollisionLast)
+	test.OK(err)
+	test.Equal(keyed, map[byte]string{'a': "avocado", 'b': "banana"})
+	_, err = stdlib.SliceKeyBy(words, first, stdlib.SliceKeyCollisionError)
+	test.True(errors.Is(err, stdlib.ErrSliceKeyCollision), "want ErrSliceKeyCollision got %v", err)
+
+	test.Equal(stdlib.SliceIntersect([]int{1, 2, 2, 3}, []int{2, 3, 4}), []int{2, 3})
+	test.Equal(stdlib.SliceDifference([]int{1, 2, 2, 3}, []int{2, 4}), []int{1, 3})
+
+	shuffled := stdlib.SliceShuffle(numbers, stdlib.NewRandom(1))
+	test.Equal(len(shuffled), len(numbers))
+	test.Equal(stdlib.SetSorted(stdlib.SliceSet(shuffled)), numbers)
+	test.Equal(len(stdlib.SliceSample(numbers, 3, nil)), 3)
+	test.Equal(len(stdlib.SliceSample(numbers, 10, nil)), 5)
+
+	squares := stdlib.SliceMapConcurrent(numbers, func(i int) int { return i * i }, 2)
+	test.Equal(squares, []int{1, 4, 9, 16, 25})
+}
+
+func TestSliceTransformE(t *testing.T) {
+	test := stdtest.NewTest(t)
+
+	tags := func(err error) []string {
+		var eg *stdlib.ErrorGroup
+		test.True(errors.As(err, &eg), "want *stdlib.ErrorGroup got %T", err)
+		var tags []string
+		for _, e := range eg.Errors {
+			tags = append(tags, e.Extras.Tags...)
+		}
+		return tags
+	}
+
+	ints, err := stdlib.SliceMapE([]string{"1", "2"}, strconv.Atoi)
+	test.OK(err)
+	test.Equal(ints, []int{1, 2})
+
+	_, err = stdlib.SliceMapE([]string{"1", "x", "y"}, strconv.Atoi)
+	test.Equal(tags(err), []string{"index[1]", "index[2]"})
+	_, err = stdlib.SliceMapE([]string{"1", "x", "y"}, strconv.Atoi, stdlib.WithTransformFailFast(true))
+	test.Equal(tags(err), []string{"index[1]"})
+
+	positive := func(s string) (bool, error) {
+		i, err := strconv.Atoi(s)
+		return i > 0, err
+	}
+	filtered, err := stdlib.SliceFilterE([]string{"-1", "2"}, positive)
+	test.OK(err)
+	test.Equal(filtered, []string{"2"})
+
+	keyed, err := stdlib.SliceToMapE([]string{"1", "2"}, strconv.Atoi)
+	test.OK(err)
+	test.Equal(keyed, map[int]string{1: "1", 2: "2"})
+
+	mapped, err := stdlib.MapMapE(map[string]string{"a": "1"}, func(_ string, v string) (int, error) {
+		return strconv.Atoi(v)
+	})
+	test.OK(err)
+	test.Equal(mapped, map[string]int{"a": 1})
+	_, err = stdlib.MapFilterE(map[string]string{"a": "1", "b": "x"}, func(_ string, v string) (bool, error) {
+		return positive(v)
+	})
+	test.Equal(tags(err), []string{"key[b]"})
+
+	asserted, err := stdlib.SliceTypeAssertE[any, string]([]any{"a", "b"})
+	test.OK(err)
+	test.Equal(asserted, []string{"a", "b"})
+	_, err = stdlib.SliceTypeAssertE[any, string]([]any{"a", 1})
+	test.True(errors.Is(err, stdlib.ErrTypeAssertionFailed), "want ErrTypeAssertionFailed got %v", err)
+}
@@ -2,7 +2,7 @@ package stdlib
 
 import (
 	"context"
-	"sync"
+	"errors"
 	"time"
 )
 
@@ -22,20 +22,100 @@ var ErrTaskTimeout = Error{
 	Namespace: ErrorNamespaceDefault,
 }
 
+// ErrTaskCancelTimeout is returned when a task cancel fn reaches its timeout.
+var ErrTaskCancelTimeout = Error{
+	Code:      "task_cancel_timeout",
+	Message:   "task cancel fn reached its timeout and was abandoned",
+	Namespace: ErrorNamespaceDefault,
+}
+
+// ErrTaskConfig is returned when running a task with an invalid config.
+var ErrTaskConfig = Error{
+	Code:      "task_config",
+	Message:   "task config is invalid",
+	Namespace: ErrorNamespaceDefault,
+}
+
 // TaskFn is a function that represents a task to be executed.
 type TaskFn func(context.Context) error
 
 // TaskCancelFn is a function that represents a task cancellation function.
 type TaskCancelFn func(ctx context.Context) error
 
+// TaskLeakFn is a function called when a task worker is abandoned after its
+// timeout. The done channel is closed once the worker eventually returns, which
+// allows callers to detect goroutines that never exit.
+type TaskLeakFn func(ctx context.Context, done <-chan struct{})
+
+// TaskHookFn is a function called at a point in the task lifecycle.
+type TaskHookFn func(ctx context.Context, result TaskResult)
+
+// TaskResult describes a single task execution and is passed to lifecycle hooks.
+type TaskResult struct {
+	// Name of the task.
+	Name string
+	// Start is when the task started.
+	Start time.Time
+	// End is when the task returned. Zero for 'OnStart'.
+	End time.Time
+	// Duration is the time between start and end. Zero for 'OnStart'.
+	Duration time.Duration
+	// Err returned by the task, if any.
+	Err error
+	// Cancelled is true if the task cancel fn was called.
+	Cancelled bool
+}
+
+// TaskMetrics describes a sink for recording task metrics.
+//
+// Implementations adapt this to a specific metrics library.
+type TaskMetrics interface {
+	// ObserveTaskDuration records the duration and result of a task execution.
+	ObserveTaskDuration(name string, duration time.Duration, err error)
+	// IncTaskTimeout increments the number of times a task reached its timeout.
+	IncTaskTimeout(name string)
+}
+
 // TaskConfig for a task execution.
 type TaskConfig struct {
+	// Name of the task, used by hooks and metrics.
+	Name string
 	// Timeout is the max duration for the task to run before cancellation.
 	Timeout time.Duration
+	// Budget is the fraction of the remaining context deadline used as the
+	// timeout. It overrides Timeout when the context has a deadline.
+	Budget float64
 	// Cancel is the function to call when the task is cancelled.
 	Cancel TaskCancelFn
 	// CancelTimeout is the max duration for the 'TaskCancelFn' to run.
 	CancelTimeout time.Duration
+	// Abandon returns as soon as the task times out instead of waiting
+	// for the worker goroutine to return.
+	Abandon bool
+	// Leak is called when the worker goroutine is abandoned.
+	Leak TaskLeakFn
+	// OnStart is called before the task runs.
+	OnStart TaskHookFn
+	// OnSuccess is called when the task returns without error.
+	OnSuccess TaskHookFn
+	// OnError is called when the task returns an error.
+	OnError TaskHookFn
+	// OnTimeout is called when the task reaches its timeout.
+	OnTimeout TaskHookFn
+	// OnCancel is called when the task context is cancelled before the task returns.
+	OnCancel TaskHookFn
+	// Metrics records task latency and timeouts.
+	Metrics TaskMetrics
+	// Clock is the source of time for timeouts and results.
+	Clock Clock
+}
+
+// WithTaskName sets the name of the task.
+func WithTaskName(name string) Option[*TaskConfig] {
+	return func(o *TaskConfig) error {
+		o.Name = name
+		return nil
+	}
 }
 
 // WithTaskTimeout sets the timeout for the task.
@@ -46,6 +126,18 @@ func WithTaskTimeout(timeout time.Duration) Option[*TaskConfig] {
 	}
 }
 
+// WithTaskBudget sets the fraction of the remaining context deadline used as
+// the timeout for the task.
+func WithTaskBudget(fraction float64) Option[*TaskConfig] {
+	return func(o *TaskConfig) error {
+		if fraction <= 0 || fraction > 1 {
+			return ErrTaskConfig.Wrapf("budget=%v must be in (0, 1]", fraction)
+		}
+		o.Budget = fraction
+		return nil
+	}
+}
+
 // WithTaskCancel sets the cancel function for the task.
 func WithTaskCancel(fn TaskCancelFn) Option[*TaskConfig] {
 	return func(o *TaskConfig) error {
@@ -54,13 +146,140 @@ func WithTaskCancel(fn TaskCancelFn) Option[*TaskConfig] {
 	}
 }
 
+// WithTaskCancelTimeout sets the timeout for the task cancel function.
+func WithTaskCancelTimeout(timeout time.Duration) Option[*TaskConfig] {
+	return func(o *TaskConfig) error {
+		o.CancelTimeout = timeout
+		return nil
+	}
+}
+
+// WithTaskAbandon sets if the worker goroutine should be abandoned, rather than
+// waited on, when the task times out.
+func WithTaskAbandon(abandon bool) Option[*TaskConfig] {
+	return func(o *TaskConfig) error {
+		o.Abandon = abandon
+		return nil
+	}
+}
+
+// WithTaskLeak sets the function called when the task worker goroutine is abandoned.
+func WithTaskLeak(fn TaskLeakFn) Option[*TaskConfig] {
+	return func(o *TaskConfig) error {
+		o.Leak = fn
+		return nil
+	}
+}
+
+// WithTaskOnStart sets the hook called before the task runs.
+func WithTaskOnStart(fn TaskHookFn) Option[*TaskConfig] {
+	return func(o *TaskConfig) error {
+		o.OnStart = fn
+		return nil
+	}
+}
+
+// WithTaskOnSuccess sets the hook called when the task returns without error.
+func WithTaskOnSuccess(fn TaskHookFn) Option[*TaskConfig] {
+	return func(o *TaskConfig) error {
+		o.OnSuccess = fn
+		return nil
+	}
+}
+
+// WithTaskOnError sets the hook called when the task returns an error.
+func WithTaskOnError(fn TaskHookFn) Option[*TaskConfig] {
+	return func(o *TaskConfig) error {
+		o.OnError = fn
+		return nil
+	}
+}
+
+// WithTaskOnTimeout sets the hook called when the task reaches its timeout.
+func WithTaskOnTimeout(fn TaskHookFn) Option[*TaskConfig] {
+	return func(o *TaskConfig) error {
+		o.OnTimeout = fn
+		return nil
+	}
+}
+
+// WithTaskOnCancel sets the hook called when the task context is cancelled.
+func WithTaskOnCancel(fn TaskHookFn) Option[*TaskConfig] {
+	return func(o *TaskConfig) error {
+		o.OnCancel = fn
+		return nil
+	}
+}
+
+// WithTaskMetrics sets the metrics sink for the task.
+func WithTaskMetrics(metrics TaskMetrics) Option[*TaskConfig] {
+	return func(o *TaskConfig) error {
+		o.Metrics = metrics
+		return nil
+	}
+}
+
+// WithTaskClock sets the clock used for task timeouts and results.
+func WithTaskClock(clock Clock) Option[*TaskConfig] {
+	return func(o *TaskConfig) error {
+		o.Clock = clock
+		return nil
+	}
+}
+
 // Task executes the given task with the provided context and options.
 func Task(ctx context.Context, task TaskFn, options ...Option[*TaskConfig]) error {
-	cfg, err := OptionApply(&TaskConfig{}, options...)
+	cfg, err := OptionApply(&TaskConfig{Clock: DefaultClock}, options...)
 	if err != nil {
 		return err
 	}
 
+	if cfg.Budget > 0 {
+		if d, ok := budget(ctx, cfg.Clock, cfg.Budget); ok {
+			// A zero timeout means none, so an exhausted budget uses the smallest one.
+			cfg.Timeout = max(d, 1)
+		}
+	}
+
+	result := TaskResult{Name: cfg.Name, Start: cfg.Clock.Now()}
+	if cfg.OnStart != nil {
+		cfg.OnStart(ctx, result)
+	}
+
+	result.Err = taskRun(ctx, task, cfg, &result)
+	result.End = cfg.Clock.Now()
+	result.Duration = result.End.Sub(result.Start)
+
+	timeout := errors.Is(result.Err, ErrTaskTimeout)
+	if cfg.Metrics != nil {
+		cfg.Metrics.ObserveTaskDuration(cfg.Name, result.Duration, result.Err)
+		if timeout {
+			cfg.Metrics.IncTaskTimeout(cfg.Name)
+		}
+	}
+
+	switch {
+	case result.Err == nil:
+		if cfg.OnSuccess != nil {
+			cfg.OnSuccess(ctx, result)
+		}
+	default:
+		if timeout && cfg.OnTimeout != nil {
+			cfg.OnTimeout(ctx, result)
+		}
+		if !timeout && ctx.Err() != nil && cfg.OnCancel != nil {
+			cfg.OnCancel(ctx, result)
+		}
+		if cfg.OnError != nil {
+			cfg.OnError(ctx, result)
+		}
+	}
+
+	return result.Err
+}
+
+// taskRun executes the given task for the config and records cancellation in the result.
+func taskRun(ctx context.Context, task TaskFn, cfg *TaskConfig, res *TaskResult) error {
 	// Tasks with no timeout or cancellation should run as standard function calls.
 	if cfg.Timeout == 0 && cfg.Cancel == nil {
 		return task(ctx)
@@ -72,39 +291,60 @@ func Task(ctx context.Context, task TaskFn, options ...Option[*TaskConfig]) erro
 		cfg.Timeout = DefaultTaskTimeout
 	}
 
-	ctx, cancel := context.WithTimeoutCause(ctx, cfg.Timeout, ErrTaskTimeout)
-	defer cancel()
+	if cfg.CancelTimeout == 0 {
+		cfg.CancelTimeout = DefaultCancelTimeout
+	}
 
-	var wg sync.WaitGroup
-	wg.Add(2)
+	ctx, cancel := cfg.Clock.WithTimeoutCause(ctx, cfg.Timeout, ErrTaskTimeout)
+	defer cancel()
 
-	eg := NewErrorGroup()
-	done := make(chan struct{})
+	result := make(chan error, 1)
+	exited := make(chan struct{})
 
 	// Worker.
 	go func() {
-		defer wg.Done()
-		eg.Append(task(ctx))
-		close(done)
+		defer close(exited)
+		result <- task(ctx)
 	}()
 
-	// Watcher.
-	go func() {
-		defer wg.Done()
-		for {
-			select {
-			case <-done:
-				return
-			case <-ctx.Done():
-				eg.Append(context.Cause(ctx))
-				if cfg.Cancel != nil {
-					eg.Append(cfg.Cancel(ctx))
-				}
-				return
-			}
+	select {
+	case err := <-result:
+		return NewErrorGroup(err).ErrorOrNil()
+	case <-ctx.Done():
+	}
+
+	eg := NewErrorGroup(context.Cause(ctx))
+	if cfg.Cancel != nil {
+		res.Cancelled = true
+		eg.Append(taskCancel(ctx, cfg))
+	}
+
+	if cfg.Abandon {
+		if cfg.Leak != nil {
+			cfg.Leak(ctx, exited)
 		}
-	}()
+		return eg.ErrorOrNil()
+	}
 
-	wg.Wait()
+	eg.Append(<-result)
 	return eg.ErrorOrNil()
 }
+
+// taskCancel calls the task cancel fn with a fresh context that carries the values
+// of the task context, but not its cancellation, bounded by the cancel timeout.
+func taskCancel(ctx context.Context, cfg *TaskConfig) error {
+	ctx, cancel := cfg.Clock.WithTimeoutCause(context.WithoutCancel(ctx), cfg.CancelTimeout, ErrTaskCancelTimeout)
+	defer cancel()
+
+	result := make(chan error, 1)
+	go func() {
+		result <- cfg.Cancel(ctx)
+	}()
+
+	select {
+	case err := <-result:
+		return err
+	case <-ctx.Done():
+		return context.Cause(ctx)
+	}
+}
@@ -0,0 +1,147 @@
+package stdlib
+
+import (
+	"context"
+	"sync"
+)
+
+// TaskGroupConfig for a task group execution.
+type TaskGroupConfig struct {
+	// Limit is the max number of tasks to run concurrently. Zero or less means no limit.
+	Limit int
+	// FailFast cancels all remaining tasks after the first failure and only
+	// returns that error. When false, all tasks run and their errors are collected.
+	FailFast bool
+}
+
+// WithTaskGroupLimit sets the max number of tasks to run concurrently.
+func WithTaskGroupLimit(limit int) Option[*TaskGroupConfig] {
+	return func(o *TaskGroupConfig) error {
+		o.Limit = limit
+		return nil
+	}
+}
+
+// WithTaskGroupFailFast sets if the group should stop after the first failure.
+func WithTaskGroupFailFast(failFast bool) Option[*TaskGroupConfig] {
+	return func(o *TaskGroupConfig) error {
+		o.FailFast = failFast
+		return nil
+	}
+}
+
+// NewTaskGroup creates a new *TaskGroup whose tasks share a context derived from ctx.
+func NewTaskGroup(ctx context.Context, options ...Option[*TaskGroupConfig]) (*TaskGroup, error) {
+	cfg, err := OptionApply(&TaskGroupConfig{}, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	g := &TaskGroup{
+		config: cfg,
+		errors: NewErrorGroup(),
+		parent: ctx,
+	}
+	g.ctx, g.cancel = context.WithCancelCause(ctx)
+	if cfg.Limit > 0 {
+		g.sem = make(chan struct{}, cfg.Limit)
+	}
+	return g, nil
+}
+
+// TaskGroup runs a collection of tasks with bounded concurrency and
+// structured cancellation.
+//
+// Each task is executed with 'Task' so its TaskConfig (timeout, cancel fn, etc.)
+// still applies. Errors from all tasks are aggregated into an ErrorGroup and
+// tagged with the name of the task that returned them.
+type TaskGroup struct {
+	// config for the group.
+	config *TaskGroupConfig
+	// ctx is shared by all tasks in the group.
+	ctx context.Context
+	// cancel cancels ctx with the cause.
+	cancel context.CancelCauseFunc
+	// parent is the context the group was created from.
+	parent context.Context
+	// sem limits the number of tasks running concurrently.
+	sem chan struct{}
+	// wg tracks running tasks.
+	wg sync.WaitGroup
+	// mu guards errors and skipped.
+	mu sync.Mutex
+	// skipped is true if a task was not run because the group context was cancelled.
+	skipped bool
+	// errors collected from tasks.
+	errors *ErrorGroup
+}
+
+// Go schedules the named task for execution within the group.
+//
+// If the group has a limit, Go blocks until a slot is available or the group
+// context is cancelled, in which case the task is not run.
+func (g *TaskGroup) Go(name string, task TaskFn, options ...Option[*TaskConfig]) {
+	if g.sem != nil {
+		select {
+		case g.sem <- struct{}{}:
+		case <-g.ctx.Done():
+			g.mu.Lock()
+			g.skipped = true
+			g.mu.Unlock()
+			return
+		}
+	}
+
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		if g.sem != nil {
+			defer func() { <-g.sem }()
+		}
+		g.append(name, Task(g.ctx, task, append([]Option[*TaskConfig]{WithTaskName(name)}, options...)...))
+	}()
+}
+
+// Wait blocks until all tasks in the group have returned and returns
+// the aggregated errors, if any.
+func (g *TaskGroup) Wait() error {
+	g.wg.Wait()
+	defer g.cancel(nil)
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	// Parent cancellation may have prevented tasks from running, so an empty
+	// group doesn't mean all tasks completed. If every task ran and succeeded,
+	// a later parent cancellation is not an error of the group.
+	if g.errors.Empty() && g.skipped && g.parent.Err() != nil {
+		g.errors.Append(context.Cause(g.parent))
+	}
+	return g.errors.ErrorOrNil()
+}
+
+// Context returns the context shared by all tasks in the group.
+func (g *TaskGroup) Context() context.Context {
+	return g.ctx
+}
+
+// append records the task error in the group, tagged with the task name.
+func (g *TaskGroup) append(name string, err error) {
+	if err == nil {
+		return
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	// In fail-fast mode, only the first failure is reported; everything after
+	// is likely a consequence of the cancellation it caused.
+	if g.config.FailFast {
+		if !g.errors.Empty() {
+			return
+		}
+		g.cancel(err)
+	}
+
+	g.errors.Append(errorWithTag(err, name))
+}
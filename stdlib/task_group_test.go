@@ -0,0 +1,145 @@
+package stdlib_test
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ahawker/stdlibx-go/stdlib"
+	"github.com/ahawker/stdlibx-go/stdtest"
+)
+
+func TestTaskGroupWait(t *testing.T) {
+	type Got struct {
+		tasks   map[string]stdlib.TaskFn
+		options []stdlib.Option[*stdlib.TaskGroupConfig]
+	}
+	type Want struct {
+		errors int
+	}
+	failing := func(ctx context.Context) error { return fmt.Errorf("task failed") }
+	blocking := func(ctx context.Context) error {
+		<-ctx.Done()
+		return context.Cause(ctx)
+	}
+	stdtest.Table[Got, Want]{
+		"pass: no tasks": {
+			Got: Got{},
+		},
+		"pass: all tasks succeed with limit": {
+			Got: Got{
+				tasks: map[string]stdlib.TaskFn{
+					"a": func(ctx context.Context) error { return nil },
+					"b": func(ctx context.Context) error { return nil },
+					"c": func(ctx context.Context) error { return nil },
+				},
+				options: []stdlib.Option[*stdlib.TaskGroupConfig]{
+					stdlib.WithTaskGroupLimit(1),
+				},
+			},
+		},
+		"fail: collect all errors": {
+			Got: Got{
+				tasks: map[string]stdlib.TaskFn{
+					"a": failing,
+					"b": failing,
+					"c": func(ctx context.Context) error { return nil },
+				},
+			},
+			Want:    Want{errors: 2},
+			WantErr: stdlib.ErrUndefined,
+		},
+		"fail: fail fast cancels remaining tasks": {
+			Got: Got{
+				tasks: map[string]stdlib.TaskFn{
+					"a": failing,
+					"b": blocking,
+					"c": blocking,
+				},
+				options: []stdlib.Option[*stdlib.TaskGroupConfig]{
+					stdlib.WithTaskGroupFailFast(true),
+				},
+			},
+			Want:    Want{errors: 1},
+			WantErr: stdlib.ErrUndefined,
+		},
+	}.Run(t, func(t *stdtest.Test, tc stdtest.Testcase[Got, Want]) {
+		g, err := stdlib.NewTaskGroup(t.Config.Context, tc.Got.options...)
+		t.OK(err)
+		for name, task := range tc.Got.tasks {
+			g.Go(name, task)
+		}
+		err = g.Wait()
+		if tc.WantErr != nil {
+			t.NotOK(err)
+			t.EqualError(err, tc.WantErr)
+			eg, ok := err.(*stdlib.ErrorGroup)
+			t.True(ok, "want *stdlib.ErrorGroup got %T", err)
+			t.Equal(eg.Len(), tc.Want.errors)
+			for _, e := range eg.Errors {
+				t.Equal(len(e.Extras.Tags), 1)
+			}
+		} else {
+			t.OK(err)
+		}
+	})
+}
+
+func TestTaskGroupLimit(t *testing.T) {
+	test := stdtest.NewTest(t)
+
+	var running, peak atomic.Int32
+	g, err := stdlib.NewTaskGroup(test.Config.Context, stdlib.WithTaskGroupLimit(2))
+	test.OK(err)
+	for i := 0; i < 8; i++ {
+		g.Go(fmt.Sprintf("task[%d]", i), func(ctx context.Context) error {
+			n := running.Add(1)
+			defer running.Add(-1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			return nil
+		})
+	}
+	test.OK(g.Wait())
+	test.True(peak.Load() <= 2, "peak concurrency %d exceeds limit", peak.Load())
+}
+
+func TestTaskGroupWaitParentCancel(t *testing.T) {
+	test := stdtest.NewTest(t)
+
+	// Cancelled after all tasks succeed, not an error of the group.
+	ctx, cancel := context.WithCancel(test.Config.Context)
+	g, err := stdlib.NewTaskGroup(ctx)
+	test.OK(err)
+	done := make(chan struct{})
+	g.Go("a", func(ctx context.Context) error {
+		defer close(done)
+		return nil
+	})
+	<-done
+	cancel()
+	test.OK(g.Wait())
+
+	// Cancelled while a task waits for a slot, so it never runs.
+	ctx, cancel = context.WithCancel(test.Config.Context)
+	defer cancel()
+	g, err = stdlib.NewTaskGroup(ctx, stdlib.WithTaskGroupLimit(1))
+	test.OK(err)
+	g.Go("a", func(ctx context.Context) error {
+		cancel()
+		<-ctx.Done()
+		time.Sleep(10 * time.Millisecond)
+		return nil
+	})
+	g.Go("b", func(ctx context.Context) error { return nil })
+	err = g.Wait()
+	test.NotOK(err)
+	test.EqualError(err, context.Canceled)
+}
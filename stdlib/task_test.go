@@ -1,73 +1,213 @@
-package stdlib
+package stdlib_test
 
 import (
 	"context"
 	"fmt"
+	"github.com/ahawker/stdlibx-go/stdlib"
 	"github.com/ahawker/stdlibx-go/stdtest"
 	"testing"
 	"time"
 )
 
 func TestTaskRun(t *testing.T) {
+	type step struct {
+		// waiters registered with the clock before advancing it.
+		waiters int
+		// advance is the duration to advance the clock by.
+		advance time.Duration
+	}
 	type Got struct {
-		task    TaskFn
-		options []Option[*TaskConfig]
+		// sleep is how long the task takes, ignoring its context.
+		sleep   time.Duration
+		steps   []step
+		options []stdlib.Option[*stdlib.TaskConfig]
 	}
 	stdtest.Table[Got, any]{
 		"pass: no timeout with defaults": {
-			Got: Got{
-				task: func(ctx context.Context) error { return nil },
-			},
+			Got: Got{},
 		},
 		"fail: task takes longer than timeout": {
 			Got: Got{
-				task: func(ctx context.Context) error {
-					time.Sleep(500 * time.Millisecond)
-					return nil
-				},
-				options: []Option[*TaskConfig]{
-					WithTaskTimeout(100 * time.Millisecond),
+				sleep: time.Hour,
+				steps: []step{{waiters: 2, advance: time.Hour}},
+				options: []stdlib.Option[*stdlib.TaskConfig]{
+					stdlib.WithTaskTimeout(100 * time.Millisecond),
 				},
 			},
-			WantErr: ErrTaskTimeout,
+			WantErr: stdlib.ErrTaskTimeout,
 		},
 		"fail: task takes longer than timeout with cancel that succeeds": {
 			Got: Got{
-				task: func(ctx context.Context) error {
-					time.Sleep(500 * time.Millisecond)
-					return nil
-				},
-				options: []Option[*TaskConfig]{
-					WithTaskTimeout(100 * time.Millisecond),
-					WithTaskCancel(func(ctx context.Context) error {
+				sleep: time.Hour,
+				steps: []step{{waiters: 2, advance: time.Hour}},
+				options: []stdlib.Option[*stdlib.TaskConfig]{
+					stdlib.WithTaskTimeout(100 * time.Millisecond),
+					stdlib.WithTaskCancel(func(ctx context.Context) error {
 						return nil
 					}),
 				},
 			},
-			WantErr: ErrTaskTimeout,
+			WantErr: stdlib.ErrTaskTimeout,
 		},
 		"fail: task takes longer than timeout with cancel that also fails": {
 			Got: Got{
-				task: func(ctx context.Context) error {
-					time.Sleep(500 * time.Millisecond)
-					return nil
-				},
-				options: []Option[*TaskConfig]{
-					WithTaskTimeout(100 * time.Millisecond),
-					WithTaskCancel(func(ctx context.Context) error {
+				sleep: time.Hour,
+				steps: []step{{waiters: 2, advance: time.Hour}},
+				options: []stdlib.Option[*stdlib.TaskConfig]{
+					stdlib.WithTaskTimeout(100 * time.Millisecond),
+					stdlib.WithTaskCancel(func(ctx context.Context) error {
 						return fmt.Errorf("cancel failed")
 					}),
 				},
 			},
-			WantErr: ErrTaskTimeout,
+			WantErr: stdlib.ErrTaskTimeout,
+		},
+		"fail: task cancel takes longer than cancel timeout": {
+			Got: Got{
+				sleep: time.Hour,
+				steps: []step{
+					{waiters: 2, advance: time.Hour},
+					{waiters: 1, advance: 100 * time.Millisecond},
+				},
+				options: []stdlib.Option[*stdlib.TaskConfig]{
+					stdlib.WithTaskTimeout(100 * time.Millisecond),
+					stdlib.WithTaskCancelTimeout(100 * time.Millisecond),
+					stdlib.WithTaskCancel(func(ctx context.Context) error {
+						<-ctx.Done()
+						return nil
+					}),
+				},
+			},
+			WantErr: stdlib.ErrTaskCancelTimeout,
+		},
+		"fail: task takes longer than timeout and worker is abandoned": {
+			Got: Got{
+				sleep: 2 * time.Hour,
+				steps: []step{{waiters: 2, advance: time.Hour}},
+				options: []stdlib.Option[*stdlib.TaskConfig]{
+					stdlib.WithTaskTimeout(100 * time.Millisecond),
+					stdlib.WithTaskAbandon(true),
+					stdlib.WithTaskLeak(func(ctx context.Context, done <-chan struct{}) {
+						select {
+						case <-done:
+							panic("abandoned worker exited before timeout")
+						default:
+						}
+					}),
+				},
+			},
+			WantErr: stdlib.ErrTaskTimeout,
 		},
 	}.Run(t, func(t *stdtest.Test, tc stdtest.Testcase[Got, any]) {
-		err := Task(t.Config.Context, tc.Got.task, tc.Got.options...)
+		clock := t.Config.Clock.(*stdlib.FakeClock)
+
+		result := make(chan error, 1)
+		go func() {
+			result <- stdlib.Task(t.Config.Context, func(ctx context.Context) error {
+				if tc.Got.sleep > 0 {
+					clock.Sleep(tc.Got.sleep)
+				}
+				return nil
+			}, append(tc.Got.options, stdlib.WithTaskClock(clock))...)
+		}()
+		for _, s := range tc.Got.steps {
+			clock.BlockUntil(s.waiters)
+			clock.Advance(s.advance)
+		}
+
+		err := <-result
 		if tc.WantErr != nil {
 			t.NotOK(err)
 			t.EqualError(err, tc.WantErr)
 		} else {
 			t.OK(err)
 		}
-	})
+	}, stdtest.WithTestFakeClock(epoch))
+}
+
+func TestTaskCancelContext(t *testing.T) {
+	test := stdtest.NewTest(t, stdtest.WithTestFakeClock(epoch))
+	clock := test.Config.Clock.(*stdlib.FakeClock)
+
+	type key struct{}
+	parent, cancel := context.WithCancel(context.WithValue(test.Config.Context, key{}, "value"))
+	defer cancel()
+
+	// The cancel fn cancels the parent itself, its context must stay live and keep the parent values.
+	var live error
+	var value any
+	result := make(chan error, 1)
+	go func() {
+		result <- stdlib.Task(parent, func(ctx context.Context) error {
+			<-ctx.Done()
+			return nil
+		},
+			stdlib.WithTaskTimeout(time.Minute),
+			stdlib.WithTaskClock(clock),
+			stdlib.WithTaskCancel(func(ctx context.Context) error {
+				cancel()
+				live, value = ctx.Err(), ctx.Value(key{})
+				return nil
+			}),
+		)
+	}()
+	clock.BlockUntil(1)
+	clock.Advance(time.Minute)
+	test.EqualError(<-result, stdlib.ErrTaskTimeout)
+	test.OK(live)
+	test.Equal(value, "value")
+	test.NotOK(parent.Err())
+}
+
+type taskMetrics struct {
+	observed []string
+	timeouts []string
+}
+
+func (m *taskMetrics) ObserveTaskDuration(name string, duration time.Duration, err error) {
+	m.observed = append(m.observed, name)
+}
+
+func (m *taskMetrics) IncTaskTimeout(name string) {
+	m.timeouts = append(m.timeouts, name)
+}
+
+func TestTaskHooks(t *testing.T) {
+	test := stdtest.NewTest(t, stdtest.WithTestFakeClock(epoch))
+	clock := test.Config.Clock.(*stdlib.FakeClock)
+
+	var calls []string
+	hook := func(name string) stdlib.TaskHookFn {
+		return func(ctx context.Context, result stdlib.TaskResult) {
+			calls = append(calls, name)
+		}
+	}
+	metrics := &taskMetrics{}
+	options := []stdlib.Option[*stdlib.TaskConfig]{
+		stdlib.WithTaskName("hooks"),
+		stdlib.WithTaskOnStart(hook("start")),
+		stdlib.WithTaskOnSuccess(hook("success")),
+		stdlib.WithTaskOnError(hook("error")),
+		stdlib.WithTaskOnTimeout(hook("timeout")),
+		stdlib.WithTaskOnCancel(hook("cancel")),
+		stdlib.WithTaskMetrics(metrics),
+	}
+
+	test.OK(stdlib.Task(test.Config.Context, func(ctx context.Context) error { return nil }, options...))
+	test.Equal(calls, []string{"start", "success"})
+
+	calls = nil
+	result := make(chan error, 1)
+	go func() {
+		result <- stdlib.Task(test.Config.Context, func(ctx context.Context) error {
+			<-ctx.Done()
+			return nil
+		}, append(options, stdlib.WithTaskTimeout(time.Minute), stdlib.WithTaskClock(clock))...)
+	}()
+	clock.BlockUntil(1)
+	clock.Advance(time.Minute)
+	test.EqualError(<-result, stdlib.ErrTaskTimeout)
+	test.Equal(calls, []string{"start", "timeout", "error"})
+	test.Equal(metrics.observed, []string{"hooks", "hooks"})
+	test.Equal(metrics.timeouts, []string{"hooks"})
 }
@@ -1,5 +1,16 @@
 package stdlib
 
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"time"
+)
+
+// DefaultValidateWorkers is the default number of validators that
+// 'ValidCheckCtx' will run concurrently.
+var DefaultValidateWorkers = runtime.GOMAXPROCS(0)
+
 // Valid is a struct that wraps an arbitrary value to indicate that it is
 // valid and has passed all checks.
 type Valid[T any] struct {
@@ -10,6 +21,9 @@ type Valid[T any] struct {
 // Validator defines functional validator for type t.
 type Validator[T any] func(t T) error
 
+// ValidatorCtx defines a context-aware functional validator for type t.
+type ValidatorCtx[T any] func(ctx context.Context, t T) error
+
 // ValidCheck applies all functional validators to type t
 // and returns the error if any fail to apply.
 //
@@ -26,3 +40,76 @@ func ValidCheck[T any](t T, validators ...Validator[T]) (*Valid[T], error) {
 	}
 	return &Valid[T]{Value: t}, nil
 }
+
+// ValidateConfig for a context-aware validation.
+type ValidateConfig struct {
+	// Workers is the max number of validators to run concurrently.
+	Workers int
+	// FailFast cancels all remaining validators after the first failure and
+	// only returns that error. When false, all validators run and their errors
+	// are collected.
+	FailFast bool
+	// Timeout is the max duration for each validator to run before cancellation.
+	Timeout time.Duration
+}
+
+// WithValidateWorkers sets the max number of validators to run concurrently.
+func WithValidateWorkers(workers int) Option[*ValidateConfig] {
+	return func(o *ValidateConfig) error {
+		o.Workers = workers
+		return nil
+	}
+}
+
+// WithValidateFailFast sets if validation should stop after the first failure.
+func WithValidateFailFast(failFast bool) Option[*ValidateConfig] {
+	return func(o *ValidateConfig) error {
+		o.FailFast = failFast
+		return nil
+	}
+}
+
+// WithValidateTimeout sets the timeout for each validator.
+func WithValidateTimeout(timeout time.Duration) Option[*ValidateConfig] {
+	return func(o *ValidateConfig) error {
+		o.Timeout = timeout
+		return nil
+	}
+}
+
+// ValidCheckCtx applies all context-aware validators to type t concurrently
+// and returns the error if any fail to apply.
+//
+// Each validator runs as a 'Task' so per-validator timeouts are handled
+// consistently with other task executions. Errors from all validators are
+// merged into a single ErrorGroup.
+//
+// If all return successfully, a Valid[T] is returned.
+func ValidCheckCtx[T any](
+	ctx context.Context,
+	t T,
+	validators []ValidatorCtx[T],
+	options ...Option[*ValidateConfig],
+) (*Valid[T], error) {
+	cfg, err := OptionApply(&ValidateConfig{Workers: DefaultValidateWorkers}, options...)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Workers <= 0 {
+		cfg.Workers = 1
+	}
+
+	g, err := NewTaskGroup(ctx, WithTaskGroupLimit(cfg.Workers), WithTaskGroupFailFast(cfg.FailFast))
+	if err != nil {
+		return nil, err
+	}
+	for i, v := range validators {
+		g.Go(fmt.Sprintf("validator[%d]", i), func(ctx context.Context) error {
+			return v(ctx, t)
+		}, WithTaskTimeout(cfg.Timeout))
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	return &Valid[T]{Value: t}, nil
+}
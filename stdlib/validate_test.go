@@ -0,0 +1,94 @@
+package stdlib_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/ahawker/stdlibx-go/stdlib"
+	"github.com/ahawker/stdlibx-go/stdtest"
+)
+
+func TestValidCheckCtx(t *testing.T) {
+	type Got struct {
+		cancel     bool
+		validators []stdlib.ValidatorCtx[int]
+		options    []stdlib.Option[*stdlib.ValidateConfig]
+	}
+	type Want struct {
+		errors int
+	}
+	positive := func(ctx context.Context, v int) error {
+		if v <= 0 {
+			return fmt.Errorf("value %d must be positive", v)
+		}
+		return nil
+	}
+	failing := func(ctx context.Context, v int) error { return fmt.Errorf("value %d is invalid", v) }
+	blocking := func(ctx context.Context, v int) error {
+		<-ctx.Done()
+		return context.Cause(ctx)
+	}
+	stdtest.Table[Got, Want]{
+		"pass: no validators": {
+			Got: Got{},
+		},
+		"pass: all validators succeed": {
+			Got: Got{
+				validators: []stdlib.ValidatorCtx[int]{positive, positive},
+				options: []stdlib.Option[*stdlib.ValidateConfig]{
+					stdlib.WithValidateWorkers(1),
+				},
+			},
+		},
+		"fail: one validator fails": {
+			Got: Got{
+				validators: []stdlib.ValidatorCtx[int]{positive, failing},
+			},
+			Want:    Want{errors: 1},
+			WantErr: stdlib.ErrUndefined,
+		},
+		"fail: collect all errors": {
+			Got: Got{
+				validators: []stdlib.ValidatorCtx[int]{failing, positive, failing, failing},
+			},
+			Want:    Want{errors: 3},
+			WantErr: stdlib.ErrUndefined,
+		},
+		"fail: fail fast returns first error": {
+			Got: Got{
+				validators: []stdlib.ValidatorCtx[int]{failing, blocking, blocking},
+				options: []stdlib.Option[*stdlib.ValidateConfig]{
+					stdlib.WithValidateFailFast(true),
+				},
+			},
+			Want:    Want{errors: 1},
+			WantErr: stdlib.ErrUndefined,
+		},
+		"fail: parent context cancelled": {
+			Got: Got{
+				cancel:     true,
+				validators: []stdlib.ValidatorCtx[int]{blocking},
+			},
+			Want:    Want{errors: 1},
+			WantErr: context.Canceled,
+		},
+	}.Run(t, func(t *stdtest.Test, tc stdtest.Testcase[Got, Want]) {
+		ctx, cancel := context.WithCancel(t.Config.Context)
+		defer cancel()
+		if tc.Got.cancel {
+			cancel()
+		}
+		valid, err := stdlib.ValidCheckCtx(ctx, 1, tc.Got.validators, tc.Got.options...)
+		if tc.WantErr != nil {
+			t.NotOK(err)
+			t.EqualError(err, tc.WantErr)
+			eg, ok := err.(*stdlib.ErrorGroup)
+			t.True(ok, "want *stdlib.ErrorGroup got %T", err)
+			t.Equal(eg.Len(), tc.Want.errors)
+		} else {
+			t.OK(err)
+			t.Equal(valid.Value, 1)
+		}
+	})
+}
@@ -66,6 +66,8 @@ var testPreconditionNoOp = func() (bool, string) {
 
 // defaultTestConfig contains default values for test configuration.
 var defaultTestConfig = &TestConfig{
+	// Clock is the default source of time for an individual test.
+	Clock: stdlib.DefaultClock,
 	// Context is the default context for an individual test.
 	Context: context.Background(),
 	// Logf is the default func called when condition of test assertion is not met.
@@ -89,6 +91,7 @@ var defaultTestConfig = &TestConfig{
 // and sane defaults.
 func NewTestConfig(options ...stdlib.Option[*TestConfig]) (*TestConfig, error) {
 	config := &TestConfig{
+		Clock:         defaultTestConfig.Clock,
 		Context:       defaultTestConfig.Context,
 		Logf:          defaultTestConfig.Logf,
 		Parallel:      defaultTestConfig.Parallel,
@@ -105,6 +108,9 @@ func NewTestConfig(options ...stdlib.Option[*TestConfig]) (*TestConfig, error) {
 
 // TestConfig defines config options for test.
 type TestConfig struct {
+	// Clock is the source of time for an individual test. Set a *stdlib.FakeClock
+	// to control the passage of time instead of sleeping.
+	Clock stdlib.Clock
 	// Context is context for an individual test.
 	Context context.Context
 	// Env contains key/value pairs to be set with 'os.SetEnv' for the scope of the test.
@@ -124,6 +130,24 @@ type TestConfig struct {
 	Timeout time.Duration
 }
 
+// WithTestClock sets the config clock.
+func WithTestClock(clock stdlib.Clock) stdlib.Option[*TestConfig] {
+	return func(t *TestConfig) error {
+		t.Clock = clock
+		return nil
+	}
+}
+
+// WithTestFakeClock sets the config clock to a new *stdlib.FakeClock at the given time.
+//
+// Options are applied for every test, so each subtest of a Table gets its own clock.
+func WithTestFakeClock(now time.Time) stdlib.Option[*TestConfig] {
+	return func(t *TestConfig) error {
+		t.Clock = stdlib.NewFakeClock(now)
+		return nil
+	}
+}
+
 // WithTestContext sets the config ctx.
 func WithTestContext(ctx context.Context) stdlib.Option[*TestConfig] {
 	return func(t *TestConfig) error {